@@ -0,0 +1,81 @@
+package log
+
+import (
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+// compressFile compresses src in place according to kind, writing
+// "<src>.gz" or "<src>.zst" next to it and removing the uncompressed
+// original. It returns the path of the compressed file, or src unchanged
+// when kind is CompressionNone.
+func compressFile(src string, kind CompressionType) (string, error) {
+	if kind == "" || kind == CompressionNone {
+		return src, nil
+	}
+
+	in, err := os.Open(src)
+	if err != nil {
+		return "", fmt.Errorf("opening %s for compression: %w", src, err)
+	}
+	defer in.Close()
+
+	var ext string
+	switch kind {
+	case CompressionGzip:
+		ext = ".gz"
+	case CompressionZstd:
+		ext = ".zst"
+	default:
+		return "", fmt.Errorf("unsupported compression type: %q", kind)
+	}
+
+	dstPath := src + ext
+
+	out, err := os.OpenFile(dstPath, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0o644)
+	if err != nil {
+		return "", fmt.Errorf("creating %s: %w", dstPath, err)
+	}
+
+	if err := writeCompressed(out, in, kind); err != nil {
+		out.Close()
+		os.Remove(dstPath)
+		return "", err
+	}
+
+	if err := out.Close(); err != nil {
+		return "", fmt.Errorf("closing %s: %w", dstPath, err)
+	}
+
+	if err := os.Remove(src); err != nil {
+		return "", fmt.Errorf("removing uncompressed %s: %w", src, err)
+	}
+
+	return dstPath, nil
+}
+
+func writeCompressed(dst io.Writer, src io.Reader, kind CompressionType) error {
+	switch kind {
+	case CompressionGzip:
+		w := gzip.NewWriter(dst)
+		if _, err := io.Copy(w, src); err != nil {
+			return fmt.Errorf("gzip-compressing: %w", err)
+		}
+		return w.Close()
+	case CompressionZstd:
+		w, err := zstd.NewWriter(dst)
+		if err != nil {
+			return fmt.Errorf("creating zstd writer: %w", err)
+		}
+		if _, err := io.Copy(w, src); err != nil {
+			return fmt.Errorf("zstd-compressing: %w", err)
+		}
+		return w.Close()
+	default:
+		return fmt.Errorf("unsupported compression type: %q", kind)
+	}
+}
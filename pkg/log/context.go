@@ -0,0 +1,20 @@
+package log
+
+import "context"
+
+// NewContext returns a copy of ctx carrying logger. Fields added to the
+// returned context via With propagate to any child context derived from
+// it, mirroring the way slog.Logger values thread through a context.
+//
+// NewContext and GetLogger are equivalent to storing and retrieving the
+// Logger directly; they exist so that code migrating from stdlib slog can
+// keep the NewContext/GetLogger naming it already knows.
+func NewContext(ctx context.Context, logger Logger) context.Context {
+	return context.WithValue(ctx, loggerKey, logger)
+}
+
+// GetLogger is an alias of FromContext kept for parity with slog's
+// GetLogger/NewContext pair.
+func GetLogger(ctx context.Context) Logger {
+	return FromContext(ctx)
+}
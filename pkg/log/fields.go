@@ -0,0 +1,87 @@
+package log
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// RouterField and ServiceField are the standard attribute keys every log
+// record is expected to carry once a router/service is known, so that
+// downstream ingestion by ELK/Loki/Datadog can group records consistently.
+const (
+	RouterField  = "traefik.router"
+	ServiceField = "traefik.service"
+)
+
+// Int adds an int field.
+func Int(key string, value int) func(logrus.Fields) {
+	return func(fields logrus.Fields) {
+		fields[key] = value
+	}
+}
+
+// Float adds a float64 field.
+func Float(key string, value float64) func(logrus.Fields) {
+	return func(fields logrus.Fields) {
+		fields[key] = value
+	}
+}
+
+// Bool adds a bool field.
+func Bool(key string, value bool) func(logrus.Fields) {
+	return func(fields logrus.Fields) {
+		fields[key] = value
+	}
+}
+
+// Duration adds a time.Duration field, rendered in the formatter's native
+// duration representation: the text formatter prints it as a Go duration
+// string (e.g. "1.5s"), the JSON formatter as an integer number of
+// nanoseconds (e.g. 1500000000).
+func Duration(key string, value time.Duration) func(logrus.Fields) {
+	return func(fields logrus.Fields) {
+		fields[key] = value
+	}
+}
+
+// Err adds the error itself under the "error" key, matching logrus's own
+// WithError convention (as opposed to err.Error()), so that hooks and
+// formatters downstream can still type-assert it back to an error - the
+// Sentry hook's Exception field depends on exactly this. A nil error is a
+// no-op so callers can unconditionally do `log.With(ctx, log.Err(err))`.
+func Err(err error) func(logrus.Fields) {
+	return func(fields logrus.Fields) {
+		if err == nil {
+			return
+		}
+		fields[logrus.ErrorKey] = err
+	}
+}
+
+// Any adds a field of arbitrary type. Prefer the typed helpers (Str, Int,
+// Float, Bool, Duration, Err) when the value's type is known, so that
+// formatters can render it without reflection or a Stringer/fmt.Sprintf
+// round-trip.
+func Any(key string, value interface{}) func(logrus.Fields) {
+	return func(fields logrus.Fields) {
+		if stringer, ok := value.(fmt.Stringer); ok {
+			fields[key] = stringer.String()
+			return
+		}
+		fields[key] = value
+	}
+}
+
+// Router returns a field option setting the traefik.router standard
+// attribute.
+func Router(name string) func(logrus.Fields) {
+	return Str(RouterField, name)
+}
+
+// Service returns a field option setting the traefik.service standard
+// attribute.
+func Service(name string) func(logrus.Fields) {
+	return Str(ServiceField, name)
+}
@@ -0,0 +1,32 @@
+package log
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+)
+
+func TestErrStoresTheErrorValueNotItsString(t *testing.T) {
+	want := errors.New("boom")
+
+	fields := make(logrus.Fields)
+	Err(want)(fields)
+
+	got, ok := fields[logrus.ErrorKey].(error)
+	if !ok {
+		t.Fatalf("fields[%q] = %#v, want the error value itself (logrus.WithError convention)", logrus.ErrorKey, fields[logrus.ErrorKey])
+	}
+	if got != want {
+		t.Fatalf("got error %v, want %v", got, want)
+	}
+}
+
+func TestErrNilIsNoOp(t *testing.T) {
+	fields := make(logrus.Fields)
+	Err(nil)(fields)
+
+	if _, ok := fields[logrus.ErrorKey]; ok {
+		t.Fatal("Err(nil) must not set a field")
+	}
+}
@@ -0,0 +1,67 @@
+package log
+
+import (
+	"path/filepath"
+	"runtime"
+	"strconv"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Format selects the on-the-wire representation of log records.
+type Format string
+
+const (
+	// FormatCommon renders records as traefik's traditional
+	// "time=... level=... msg=..." text line.
+	FormatCommon Format = "common"
+	// FormatJSON renders records as one JSON object per line, suitable
+	// for ingestion by ELK, Loki, or Datadog.
+	FormatJSON Format = "json"
+)
+
+// ConfigureFormat sets the standard logger's formatter and, for JSON,
+// enables caller reporting so that every record carries a "caller"
+// attribute alongside the standard "time", "level" and "msg" fields.
+func ConfigureFormat(format Format) {
+	switch format {
+	case FormatJSON:
+		logrus.SetReportCaller(true)
+		logrus.SetFormatter(&jsonFormatter{
+			JSONFormatter: logrus.JSONFormatter{
+				CallerPrettyfier: prettyCaller,
+				FieldMap: logrus.FieldMap{
+					logrus.FieldKeyFile: "caller",
+				},
+			},
+		})
+	default:
+		logrus.SetReportCaller(false)
+		logrus.SetFormatter(&logrus.TextFormatter{
+			FullTimestamp: true,
+		})
+	}
+}
+
+// jsonFormatter wraps logrus.JSONFormatter to guarantee the
+// traefik.router/traefik.service standard attributes are always present,
+// even as empty strings, so downstream indices never see a record missing
+// a field their mapping expects.
+type jsonFormatter struct {
+	logrus.JSONFormatter
+}
+
+func (f *jsonFormatter) Format(entry *logrus.Entry) ([]byte, error) {
+	if _, ok := entry.Data[RouterField]; !ok {
+		entry.Data[RouterField] = ""
+	}
+	if _, ok := entry.Data[ServiceField]; !ok {
+		entry.Data[ServiceField] = ""
+	}
+
+	return f.JSONFormatter.Format(entry)
+}
+
+func prettyCaller(frame *runtime.Frame) (function string, file string) {
+	return frame.Function, filepath.Base(frame.File) + ":" + strconv.Itoa(frame.Line)
+}
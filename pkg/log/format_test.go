@@ -0,0 +1,67 @@
+package log
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+)
+
+func TestJSONFormatterDefaultsStandardAttributes(t *testing.T) {
+	f := &jsonFormatter{}
+
+	entry := &logrus.Entry{
+		Logger:  logrus.StandardLogger(),
+		Data:    logrus.Fields{},
+		Message: "hello",
+		Level:   logrus.InfoLevel,
+	}
+
+	line, err := f.Format(entry)
+	if err != nil {
+		t.Fatalf("Format: %v", err)
+	}
+
+	var decoded map[string]interface{}
+	if err := json.Unmarshal(line, &decoded); err != nil {
+		t.Fatalf("unmarshaling formatted line: %v", err)
+	}
+
+	for _, field := range []string{RouterField, ServiceField} {
+		v, ok := decoded[field]
+		if !ok {
+			t.Fatalf("expected %q to default to empty string, field is absent", field)
+		}
+		if v != "" {
+			t.Fatalf("expected %q to default to empty string, got %v", field, v)
+		}
+	}
+}
+
+func TestJSONFormatterPreservesExplicitStandardAttributes(t *testing.T) {
+	f := &jsonFormatter{}
+
+	entry := &logrus.Entry{
+		Logger: logrus.StandardLogger(),
+		Data: logrus.Fields{
+			RouterField:  "my-router",
+			ServiceField: "my-service",
+		},
+		Message: "hello",
+		Level:   logrus.InfoLevel,
+	}
+
+	line, err := f.Format(entry)
+	if err != nil {
+		t.Fatalf("Format: %v", err)
+	}
+
+	var decoded map[string]interface{}
+	if err := json.Unmarshal(line, &decoded); err != nil {
+		t.Fatalf("unmarshaling formatted line: %v", err)
+	}
+
+	if decoded[RouterField] != "my-router" || decoded[ServiceField] != "my-service" {
+		t.Fatalf("explicit standard attributes were overwritten: %v", decoded)
+	}
+}
@@ -0,0 +1,177 @@
+package log
+
+import (
+	"io"
+	"sync"
+	"sync/atomic"
+
+	"github.com/sirupsen/logrus"
+)
+
+// defaultHookQueueSize is the number of entries buffered per hook before
+// new entries start being dropped instead of blocking the caller.
+const defaultHookQueueSize = 1024
+
+// hookRegistry is the process-wide set of hooks wired into mainLogger.
+// Contextual loggers returned by FromContext are derived from mainLogger
+// and share its logrus hooks, so they fire the same hooks without
+// consulting this registry.
+var hookRegistry = struct {
+	mu    sync.Mutex
+	hooks map[string]*nonBlockingHook
+}{hooks: make(map[string]*nonBlockingHook)}
+
+// AddHook registers a named logrus.Hook on the main logger. The hook runs
+// on its own goroutine behind a bounded queue so that a slow sink (a
+// remote Elasticsearch cluster, a Graylog server under load, ...) cannot
+// stall request handling; once the queue is full, further entries are
+// dropped and counted rather than blocking. Registering a hook under a
+// name that is already in use replaces the previous one.
+//
+// The hook is always registered on logrus's standard logger, which backs
+// the main logger by default; a logger installed via SetLogger is left
+// untouched and does not fire it.
+func AddHook(name string, hook logrus.Hook) {
+	wrapped := newNonBlockingHook(name, hook, defaultHookQueueSize)
+
+	hookRegistry.mu.Lock()
+	if existing, ok := hookRegistry.hooks[name]; ok {
+		existing.Close()
+	}
+	hookRegistry.hooks[name] = wrapped
+	hookRegistry.mu.Unlock()
+
+	logrus.AddHook(wrapped)
+}
+
+// RemoveHook stops and forgets the hook registered under name. It is a
+// no-op if no hook is registered under that name.
+//
+// Logrus has no API to unregister a hook once added: it calls Levels()
+// once, at AddHook time, to bucket the hook into its internal per-level
+// map, and never consults it again before calling Fire. So the wrapper
+// stays registered with logrus forever; RemoveHook instead marks it
+// closed so that Fire stops enqueuing entries for it.
+func RemoveHook(name string) {
+	hookRegistry.mu.Lock()
+	defer hookRegistry.mu.Unlock()
+
+	hook, ok := hookRegistry.hooks[name]
+	if !ok {
+		return
+	}
+
+	hook.Close()
+	delete(hookRegistry.hooks, name)
+}
+
+// HookDroppedTotal returns the number of entries dropped by the named hook
+// because its queue was full. This package does not register a metric for
+// it; the count is only available through this function.
+func HookDroppedTotal(name string) uint64 {
+	hookRegistry.mu.Lock()
+	defer hookRegistry.mu.Unlock()
+
+	hook, ok := hookRegistry.hooks[name]
+	if !ok {
+		return 0
+	}
+
+	return hook.dropped.Load()
+}
+
+// nonBlockingHook wraps a logrus.Hook so that Fire never blocks the
+// calling goroutine: entries are copied onto a bounded channel and
+// delivered to the wrapped hook from a single dedicated goroutine.
+type nonBlockingHook struct {
+	name    string
+	wrapped logrus.Hook
+	queue   chan *logrus.Entry
+	dropped atomic.Uint64
+	closed  atomic.Bool
+
+	closeOnce  sync.Once
+	stopRunner chan struct{}
+}
+
+func newNonBlockingHook(name string, wrapped logrus.Hook, queueSize int) *nonBlockingHook {
+	h := &nonBlockingHook{
+		name:       name,
+		wrapped:    wrapped,
+		queue:      make(chan *logrus.Entry, queueSize),
+		stopRunner: make(chan struct{}),
+	}
+
+	go h.run()
+
+	return h
+}
+
+func (h *nonBlockingHook) Levels() []logrus.Level {
+	return h.wrapped.Levels()
+}
+
+func (h *nonBlockingHook) Fire(entry *logrus.Entry) error {
+	// logrus only reads Levels() once, at registration time, so a closed
+	// hook is still called here for as long as the process runs. Bail out
+	// before touching the queue rather than relying on Levels() to ever
+	// stop logrus from calling Fire.
+	if h.closed.Load() {
+		return nil
+	}
+
+	clone := cloneEntry(entry)
+
+	select {
+	case h.queue <- clone:
+	default:
+		h.dropped.Add(1)
+	}
+
+	return nil
+}
+
+// Close stops the delivery goroutine and, if the wrapped hook keeps state
+// of its own (e.g. elasticsearchHook's buffered-but-not-yet-flushed
+// entries), gives it a chance to release it via io.Closer.
+func (h *nonBlockingHook) Close() {
+	h.closeOnce.Do(func() {
+		h.closed.Store(true)
+		close(h.stopRunner)
+
+		if closer, ok := h.wrapped.(io.Closer); ok {
+			if err := closer.Close(); err != nil {
+				mainLogger.WithError(err).WithField("hook", h.name).Warn("Log hook failed to close cleanly")
+			}
+		}
+	})
+}
+
+func (h *nonBlockingHook) run() {
+	for {
+		select {
+		case entry := <-h.queue:
+			if err := h.wrapped.Fire(entry); err != nil {
+				mainLogger.WithError(err).WithField("hook", h.name).Warn("Log hook failed to process entry")
+			}
+		case <-h.stopRunner:
+			return
+		}
+	}
+}
+
+func cloneEntry(entry *logrus.Entry) *logrus.Entry {
+	data := make(logrus.Fields, len(entry.Data))
+	for k, v := range entry.Data {
+		data[k] = v
+	}
+
+	clone := logrus.NewEntry(entry.Logger)
+	clone.Data = data
+	clone.Time = entry.Time
+	clone.Level = entry.Level
+	clone.Message = entry.Message
+	clone.Caller = entry.Caller
+
+	return clone
+}
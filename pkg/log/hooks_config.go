@@ -0,0 +1,55 @@
+package log
+
+import "fmt"
+
+// HooksConfig configures the built-in logrus hooks. Each field is optional;
+// a nil field leaves that hook unconfigured.
+type HooksConfig struct {
+	Elasticsearch *ElasticsearchHookConfig `description:"Elasticsearch bulk hook" json:"elasticsearch,omitempty" toml:"elasticsearch,omitempty" yaml:"elasticsearch,omitempty" export:"true"`
+	Graylog       *GraylogHookConfig       `description:"Graylog GELF hook" json:"graylog,omitempty" toml:"graylog,omitempty" yaml:"graylog,omitempty" export:"true"`
+	Sentry        *SentryHookConfig        `description:"Sentry hook" json:"sentry,omitempty" toml:"sentry,omitempty" yaml:"sentry,omitempty" export:"true"`
+	PathMap       *PathMapHookConfig       `description:"Per-level file path map hook" json:"pathMap,omitempty" toml:"pathMap,omitempty" yaml:"pathMap,omitempty" export:"true"`
+}
+
+// ConfigureHooks instantiates and registers the hooks described by cfg
+// under well-known names ("elasticsearch", "graylog", "sentry",
+// "pathMap"), replacing any hook already registered under those names.
+func ConfigureHooks(cfg *HooksConfig) error {
+	if cfg == nil {
+		return nil
+	}
+
+	if cfg.Elasticsearch != nil {
+		hook, err := NewElasticsearchHook(cfg.Elasticsearch)
+		if err != nil {
+			return fmt.Errorf("configuring elasticsearch hook: %w", err)
+		}
+		AddHook("elasticsearch", hook)
+	}
+
+	if cfg.Graylog != nil {
+		hook, err := NewGraylogHook(cfg.Graylog)
+		if err != nil {
+			return fmt.Errorf("configuring graylog hook: %w", err)
+		}
+		AddHook("graylog", hook)
+	}
+
+	if cfg.Sentry != nil {
+		hook, err := NewSentryHook(cfg.Sentry)
+		if err != nil {
+			return fmt.Errorf("configuring sentry hook: %w", err)
+		}
+		AddHook("sentry", hook)
+	}
+
+	if cfg.PathMap != nil {
+		hook, err := NewPathMapHook(cfg.PathMap)
+		if err != nil {
+			return fmt.Errorf("configuring path map hook: %w", err)
+		}
+		AddHook("pathMap", hook)
+	}
+
+	return nil
+}
@@ -0,0 +1,217 @@
+package log
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// defaultElasticsearchBatchSize and defaultElasticsearchFlushInterval bound
+// how long an entry can sit in the hook's buffer before it reaches
+// Elasticsearch: whichever limit is hit first triggers a flush.
+const (
+	defaultElasticsearchBatchSize     = 100
+	defaultElasticsearchFlushInterval = 5 * time.Second
+)
+
+// ElasticsearchHookConfig configures the built-in Elasticsearch bulk hook.
+type ElasticsearchHookConfig struct {
+	// URL is the Elasticsearch base URL, e.g. "https://es.internal:9200".
+	URL string `description:"Elasticsearch base URL" json:"url,omitempty" toml:"url,omitempty" yaml:"url,omitempty"`
+	// Index is the target index name.
+	Index string `description:"Elasticsearch index name" json:"index,omitempty" toml:"index,omitempty" yaml:"index,omitempty"`
+	// Username and Password are optional basic-auth credentials.
+	Username string `description:"Basic auth username" json:"username,omitempty" toml:"username,omitempty" yaml:"username,omitempty"`
+	Password string `description:"Basic auth password" json:"password,omitempty" toml:"password,omitempty" yaml:"password,omitempty" loggable:"false"`
+	// Level is the minimum level forwarded to Elasticsearch.
+	Level string `description:"Minimum level forwarded to Elasticsearch" json:"level,omitempty" toml:"level,omitempty" yaml:"level,omitempty"`
+	// BatchSize is the number of entries buffered before a bulk request
+	// is sent. Defaults to 100.
+	BatchSize int `description:"Number of entries buffered before a bulk request is sent" json:"batchSize,omitempty" toml:"batchSize,omitempty" yaml:"batchSize,omitempty"`
+	// FlushInterval is the longest an entry can sit in the buffer before
+	// a bulk request is sent, even if BatchSize hasn't been reached.
+	// Defaults to 5s.
+	FlushInterval time.Duration `description:"Longest an entry can sit buffered before a bulk request is sent" json:"flushInterval,omitempty" toml:"flushInterval,omitempty" yaml:"flushInterval,omitempty"`
+}
+
+// elasticsearchHook is a logrus.Hook that indexes entries into
+// Elasticsearch via the _bulk API: Fire buffers the entry and only issues
+// an HTTP request once BatchSize entries have accumulated or
+// FlushInterval has elapsed, whichever comes first. AddHook wraps it in a
+// nonBlockingHook, so Fire may block on the HTTP
+// round trip without stalling request handling.
+type elasticsearchHook struct {
+	cfg    *ElasticsearchHookConfig
+	levels []logrus.Level
+	client *http.Client
+
+	mu     sync.Mutex
+	buffer []map[string]interface{}
+
+	stopOnce sync.Once
+	stop     chan struct{}
+	done     chan struct{}
+}
+
+// NewElasticsearchHook builds the Elasticsearch hook described by cfg and
+// starts its background flush loop. Call Close to flush any buffered
+// entries and stop that loop.
+func NewElasticsearchHook(cfg *ElasticsearchHookConfig) (logrus.Hook, error) {
+	if cfg == nil || cfg.URL == "" || cfg.Index == "" {
+		return nil, fmt.Errorf("elasticsearch hook requires a url and an index")
+	}
+
+	level := logrus.InfoLevel
+	if cfg.Level != "" {
+		parsed, err := logrus.ParseLevel(cfg.Level)
+		if err != nil {
+			return nil, fmt.Errorf("parsing elasticsearch hook level: %w", err)
+		}
+		level = parsed
+	}
+
+	batchSize := cfg.BatchSize
+	if batchSize <= 0 {
+		batchSize = defaultElasticsearchBatchSize
+	}
+
+	flushInterval := cfg.FlushInterval
+	if flushInterval <= 0 {
+		flushInterval = defaultElasticsearchFlushInterval
+	}
+
+	h := &elasticsearchHook{
+		cfg:    cfg,
+		levels: logrus.AllLevels[:level+1],
+		client: &http.Client{Timeout: 10 * time.Second},
+		buffer: make([]map[string]interface{}, 0, batchSize),
+		stop:   make(chan struct{}),
+		done:   make(chan struct{}),
+	}
+	h.cfg.BatchSize = batchSize
+	h.cfg.FlushInterval = flushInterval
+
+	go h.runFlushLoop()
+
+	return h, nil
+}
+
+func (h *elasticsearchHook) Levels() []logrus.Level {
+	return h.levels
+}
+
+func (h *elasticsearchHook) Fire(entry *logrus.Entry) error {
+	doc := make(map[string]interface{}, len(entry.Data)+3)
+	for k, v := range entry.Data {
+		doc[k] = v
+	}
+	doc["@timestamp"] = entry.Time.UTC().Format(time.RFC3339Nano)
+	doc["level"] = entry.Level.String()
+	doc["message"] = entry.Message
+
+	h.mu.Lock()
+	h.buffer = append(h.buffer, doc)
+	full := len(h.buffer) >= h.cfg.BatchSize
+	h.mu.Unlock()
+
+	if full {
+		return h.flush()
+	}
+
+	return nil
+}
+
+// Close flushes any buffered entries and stops the background flush loop.
+// It implements io.Closer so nonBlockingHook.Close picks it up.
+func (h *elasticsearchHook) Close() error {
+	h.stopOnce.Do(func() { close(h.stop) })
+	<-h.done
+
+	return h.flush()
+}
+
+func (h *elasticsearchHook) runFlushLoop() {
+	defer close(h.done)
+
+	ticker := time.NewTicker(h.cfg.FlushInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-h.stop:
+			return
+		case <-ticker.C:
+			if err := h.flush(); err != nil {
+				mainLogger.WithError(err).Warn("Elasticsearch bulk flush failed")
+			}
+		}
+	}
+}
+
+func (h *elasticsearchHook) flush() error {
+	h.mu.Lock()
+	if len(h.buffer) == 0 {
+		h.mu.Unlock()
+		return nil
+	}
+	batch := h.buffer
+	h.buffer = make([]map[string]interface{}, 0, h.cfg.BatchSize)
+	h.mu.Unlock()
+
+	return h.bulkIndex(batch)
+}
+
+// bulkIndex sends docs to Elasticsearch's _bulk endpoint as newline
+// delimited JSON: an "index" action line followed by the document, for
+// each entry in the batch.
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/docs-bulk.html
+func (h *elasticsearchHook) bulkIndex(docs []map[string]interface{}) error {
+	var body bytes.Buffer
+
+	action, err := json.Marshal(map[string]interface{}{
+		"index": map[string]string{"_index": h.cfg.Index},
+	})
+	if err != nil {
+		return fmt.Errorf("marshaling bulk action line: %w", err)
+	}
+
+	for _, doc := range docs {
+		line, err := json.Marshal(doc)
+		if err != nil {
+			return fmt.Errorf("marshaling elasticsearch document: %w", err)
+		}
+
+		body.Write(action)
+		body.WriteByte('\n')
+		body.Write(line)
+		body.WriteByte('\n')
+	}
+
+	url := fmt.Sprintf("%s/_bulk", h.cfg.URL)
+
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(body.Bytes()))
+	if err != nil {
+		return fmt.Errorf("creating elasticsearch bulk request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-ndjson")
+	if h.cfg.Username != "" {
+		req.SetBasicAuth(h.cfg.Username, h.cfg.Password)
+	}
+
+	resp, err := h.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("sending bulk request to elasticsearch: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("elasticsearch bulk request returned status %s", resp.Status)
+	}
+
+	return nil
+}
@@ -0,0 +1,164 @@
+package log
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// GraylogHookConfig configures the built-in Graylog GELF hook.
+type GraylogHookConfig struct {
+	// Network is "udp" or "tcp".
+	Network string `description:"Network used to reach Graylog (udp or tcp)" json:"network,omitempty" toml:"network,omitempty" yaml:"network,omitempty"`
+	// Address is the "host:port" of the GELF input.
+	Address string `description:"Graylog GELF input address" json:"address,omitempty" toml:"address,omitempty" yaml:"address,omitempty"`
+	// Facility is reported as the GELF "facility" field.
+	Facility string `description:"GELF facility" json:"facility,omitempty" toml:"facility,omitempty" yaml:"facility,omitempty"`
+	// Extra are static fields added to every message, sent as GELF
+	// "_"-prefixed additional fields.
+	Extra map[string]string `description:"Static extra fields added to every GELF message" json:"extra,omitempty" toml:"extra,omitempty" yaml:"extra,omitempty"`
+	// Level is the minimum level forwarded to Graylog.
+	Level string `description:"Minimum level forwarded to Graylog" json:"level,omitempty" toml:"level,omitempty" yaml:"level,omitempty"`
+}
+
+// gelfMessage is the GELF 1.1 wire format.
+// https://go2docs.graylog.org/current/getting_in_log_data/gelf.html
+type gelfMessage struct {
+	Version      string  `json:"version"`
+	Host         string  `json:"host"`
+	ShortMessage string  `json:"short_message"`
+	Timestamp    float64 `json:"timestamp"`
+	Level        int32   `json:"level"`
+	Facility     string  `json:"facility,omitempty"`
+}
+
+type graylogHook struct {
+	cfg    *GraylogHookConfig
+	levels []logrus.Level
+	conn   net.Conn
+	host   string
+}
+
+// NewGraylogHook builds the GELF UDP/TCP hook described by cfg.
+func NewGraylogHook(cfg *GraylogHookConfig) (logrus.Hook, error) {
+	if cfg == nil || cfg.Address == "" {
+		return nil, fmt.Errorf("graylog hook requires an address")
+	}
+
+	network := cfg.Network
+	if network == "" {
+		network = "udp"
+	}
+
+	conn, err := net.Dial(network, cfg.Address)
+	if err != nil {
+		return nil, fmt.Errorf("dialing graylog: %w", err)
+	}
+
+	level := logrus.InfoLevel
+	if cfg.Level != "" {
+		parsed, err := logrus.ParseLevel(cfg.Level)
+		if err != nil {
+			return nil, fmt.Errorf("parsing graylog hook level: %w", err)
+		}
+		level = parsed
+	}
+
+	hostname, err := os.Hostname()
+	if err != nil {
+		hostname = "traefik"
+	}
+
+	return &graylogHook{
+		cfg:    cfg,
+		levels: logrus.AllLevels[:level+1],
+		conn:   conn,
+		host:   hostname,
+	}, nil
+}
+
+func (h *graylogHook) Levels() []logrus.Level {
+	return h.levels
+}
+
+// syslogLevel maps a logrus level to the numeric syslog severity GELF
+// expects in its "level" field.
+func syslogLevel(level logrus.Level) int32 {
+	switch level {
+	case logrus.PanicLevel, logrus.FatalLevel:
+		return 2 // critical
+	case logrus.ErrorLevel:
+		return 3 // error
+	case logrus.WarnLevel:
+		return 4 // warning
+	case logrus.InfoLevel:
+		return 6 // informational
+	default:
+		return 7 // debug
+	}
+}
+
+func (h *graylogHook) Fire(entry *logrus.Entry) error {
+	msg := gelfMessage{
+		Version:      "1.1",
+		Host:         h.host,
+		ShortMessage: entry.Message,
+		Timestamp:    float64(entry.Time.UnixNano()) / float64(time.Second),
+		Level:        syslogLevel(entry.Level),
+		Facility:     h.cfg.Facility,
+	}
+
+	payload, err := json.Marshal(msg)
+	if err != nil {
+		return fmt.Errorf("marshaling GELF message: %w", err)
+	}
+
+	fields := make(map[string]interface{}, len(entry.Data)+len(h.cfg.Extra))
+	for k, v := range h.cfg.Extra {
+		fields["_"+k] = v
+	}
+	for k, v := range entry.Data {
+		fields["_"+k] = v
+	}
+
+	if len(fields) > 0 {
+		extra, err := json.Marshal(fields)
+		if err != nil {
+			return fmt.Errorf("marshaling GELF extra fields: %w", err)
+		}
+		payload = mergeJSONObjects(payload, extra)
+	}
+
+	// GELF over TCP delimits messages with a null byte; over UDP each
+	// datagram is one message.
+	if strings.HasPrefix(h.cfg.Network, "tcp") {
+		payload = append(payload, 0)
+	}
+
+	if _, err := h.conn.Write(payload); err != nil {
+		return fmt.Errorf("writing GELF message to %s: %w", h.cfg.Address, err)
+	}
+
+	return nil
+}
+
+// mergeJSONObjects merges two flat JSON objects by concatenating their
+// members. Keys are not deduplicated: a key present in both objects
+// appears twice in the result. It assumes both arguments are well-formed
+// JSON objects.
+func mergeJSONObjects(a, b []byte) []byte {
+	merged := append([]byte(nil), a[:len(a)-1]...)
+	if len(b) > 2 {
+		merged = append(merged, ',')
+		merged = append(merged, b[1:]...)
+	} else {
+		merged = append(merged, '}')
+	}
+
+	return merged
+}
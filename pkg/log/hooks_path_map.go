@@ -0,0 +1,82 @@
+package log
+
+import (
+	"fmt"
+	"os"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+)
+
+// PathMapHookConfig configures a hook that routes each level to its own
+// file, lfshook-style (https://github.com/rifflock/lfshook).
+type PathMapHookConfig struct {
+	// Paths maps a level name ("info", "warning", "error", ...) to the
+	// file it should be appended to. Levels not present in the map are
+	// not written anywhere by this hook.
+	Paths map[string]string `description:"Map of level name to destination file path" json:"paths,omitempty" toml:"paths,omitempty" yaml:"paths,omitempty"`
+}
+
+type pathMapHook struct {
+	formatter logrus.Formatter
+	levels    []logrus.Level
+
+	mu    sync.Mutex
+	files map[logrus.Level]*os.File
+}
+
+// NewPathMapHook builds the per-level path map hook described by cfg.
+func NewPathMapHook(cfg *PathMapHookConfig) (logrus.Hook, error) {
+	if cfg == nil || len(cfg.Paths) == 0 {
+		return nil, fmt.Errorf("path map hook requires at least one level/path pair")
+	}
+
+	files := make(map[logrus.Level]*os.File, len(cfg.Paths))
+	var levels []logrus.Level
+
+	for name, path := range cfg.Paths {
+		level, err := logrus.ParseLevel(name)
+		if err != nil {
+			return nil, fmt.Errorf("parsing path map hook level %q: %w", name, err)
+		}
+
+		file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE|os.O_APPEND, 0o644)
+		if err != nil {
+			return nil, fmt.Errorf("opening path map hook file %s: %w", path, err)
+		}
+
+		files[level] = file
+		levels = append(levels, level)
+	}
+
+	return &pathMapHook{
+		formatter: &logrus.TextFormatter{},
+		levels:    levels,
+		files:     files,
+	}, nil
+}
+
+func (h *pathMapHook) Levels() []logrus.Level {
+	return h.levels
+}
+
+func (h *pathMapHook) Fire(entry *logrus.Entry) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	file, ok := h.files[entry.Level]
+	if !ok {
+		return nil
+	}
+
+	line, err := h.formatter.Format(entry)
+	if err != nil {
+		return fmt.Errorf("formatting entry for path map hook: %w", err)
+	}
+
+	if _, err := file.Write(line); err != nil {
+		return fmt.Errorf("writing to path map hook file: %w", err)
+	}
+
+	return nil
+}
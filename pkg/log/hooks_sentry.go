@@ -0,0 +1,100 @@
+package log
+
+import (
+	"fmt"
+
+	"github.com/getsentry/sentry-go"
+	"github.com/sirupsen/logrus"
+)
+
+// SentryHookConfig configures the built-in Sentry hook.
+type SentryHookConfig struct {
+	// DSN is the Sentry project DSN.
+	DSN string `description:"Sentry DSN" json:"dsn,omitempty" toml:"dsn,omitempty" yaml:"dsn,omitempty" loggable:"false"`
+	// Environment is reported on every event.
+	Environment string `description:"Sentry environment tag" json:"environment,omitempty" toml:"environment,omitempty" yaml:"environment,omitempty"`
+	// Level is the minimum level forwarded to Sentry.
+	Level string `description:"Minimum level forwarded to Sentry" json:"level,omitempty" toml:"level,omitempty" yaml:"level,omitempty"`
+}
+
+type sentryHook struct {
+	cfg    *SentryHookConfig
+	levels []logrus.Level
+	client *sentry.Client
+}
+
+// NewSentryHook builds the Sentry hook described by cfg.
+func NewSentryHook(cfg *SentryHookConfig) (logrus.Hook, error) {
+	if cfg == nil || cfg.DSN == "" {
+		return nil, fmt.Errorf("sentry hook requires a DSN")
+	}
+
+	client, err := sentry.NewClient(sentry.ClientOptions{
+		Dsn:         cfg.DSN,
+		Environment: cfg.Environment,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("creating sentry client: %w", err)
+	}
+
+	level := logrus.ErrorLevel
+	if cfg.Level != "" {
+		parsed, err := logrus.ParseLevel(cfg.Level)
+		if err != nil {
+			return nil, fmt.Errorf("parsing sentry hook level: %w", err)
+		}
+		level = parsed
+	}
+
+	return &sentryHook{
+		cfg:    cfg,
+		levels: logrus.AllLevels[:level+1],
+		client: client,
+	}, nil
+}
+
+func (h *sentryHook) Levels() []logrus.Level {
+	return h.levels
+}
+
+func (h *sentryHook) Fire(entry *logrus.Entry) error {
+	event := sentry.NewEvent()
+	event.Message = entry.Message
+	event.Level = toSentryLevel(entry.Level)
+	event.Timestamp = entry.Time
+	event.Environment = h.cfg.Environment
+	event.Extra = make(map[string]interface{}, len(entry.Data))
+	for k, v := range entry.Data {
+		event.Extra[k] = v
+	}
+
+	// log.Err stores the error value itself, matching logrus's own
+	// WithError convention, but callers are also free to set this field
+	// directly to a plain string - handle both so the Exception doesn't
+	// silently go missing depending on which convention a call site used.
+	switch v := entry.Data[logrus.ErrorKey].(type) {
+	case error:
+		event.Exception = []sentry.Exception{{Type: fmt.Sprintf("%T", v), Value: v.Error()}}
+	case string:
+		event.Exception = []sentry.Exception{{Type: "error", Value: v}}
+	}
+
+	h.client.CaptureEvent(event, nil, sentry.NewScope())
+
+	return nil
+}
+
+func toSentryLevel(level logrus.Level) sentry.Level {
+	switch level {
+	case logrus.PanicLevel, logrus.FatalLevel:
+		return sentry.LevelFatal
+	case logrus.ErrorLevel:
+		return sentry.LevelError
+	case logrus.WarnLevel:
+		return sentry.LevelWarning
+	case logrus.InfoLevel:
+		return sentry.LevelInfo
+	default:
+		return sentry.LevelDebug
+	}
+}
@@ -0,0 +1,78 @@
+package log
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// blockingHook blocks inside Fire until release is closed, so tests can
+// fill the nonBlockingHook's queue deterministically.
+type blockingHook struct {
+	release chan struct{}
+	fired   chan struct{}
+}
+
+func (h *blockingHook) Levels() []logrus.Level { return logrus.AllLevels }
+
+func (h *blockingHook) Fire(*logrus.Entry) error {
+	h.fired <- struct{}{}
+	<-h.release
+	return nil
+}
+
+func TestNonBlockingHookDropsWhenQueueFull(t *testing.T) {
+	inner := &blockingHook{release: make(chan struct{}), fired: make(chan struct{}, 1)}
+	h := newNonBlockingHook("test", inner, 1)
+	defer h.Close()
+
+	entry := logrus.NewEntry(logrus.StandardLogger())
+
+	// First Fire is picked up by run() and blocks inner.Fire; wait for it
+	// to be in flight so the queue below is empty and predictable.
+	_ = h.Fire(entry)
+	<-inner.fired
+
+	// Queue capacity is 1: this one is buffered...
+	_ = h.Fire(entry)
+	// ...and this one must be dropped.
+	_ = h.Fire(entry)
+
+	if got := h.dropped.Load(); got != 1 {
+		t.Fatalf("dropped = %d, want 1", got)
+	}
+
+	close(inner.release)
+}
+
+func TestNonBlockingHookStopsFiringAfterClose(t *testing.T) {
+	inner := &blockingHook{release: make(chan struct{}), fired: make(chan struct{}, 1)}
+	close(inner.release) // Fire returns immediately once called.
+
+	h := newNonBlockingHook("test", inner, 4)
+	h.Close()
+
+	entry := logrus.NewEntry(logrus.StandardLogger())
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_ = h.Fire(entry)
+		}()
+	}
+	wg.Wait()
+
+	select {
+	case <-h.queue:
+		t.Fatal("closed hook must not enqueue entries")
+	case <-time.After(10 * time.Millisecond):
+	}
+
+	if got := h.dropped.Load(); got != 0 {
+		t.Fatalf("dropped = %d, want 0 (closed hook should skip the queue entirely, not count drops)", got)
+	}
+}
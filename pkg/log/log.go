@@ -7,9 +7,6 @@ import (
 	"os"
 	"time"
 
-	"github.com/aws/aws-sdk-go/aws"
-	"github.com/aws/aws-sdk-go/aws/session"
-	"github.com/aws/aws-sdk-go/service/s3/s3manager"
 	"github.com/sirupsen/logrus"
 )
 
@@ -148,49 +145,33 @@ func RotateFile() error {
 	return nil
 }
 
-func UploadLogs(path string) {
-	logFilePath = path
-	environment, ok := os.LookupEnv("VOLT_ENVIRONMENT")
-	if !ok || logFilePath == "" {
-		fmt.Println("VOLT_ENVIRONMENT is not set or the log file path is not set. Not uploading logs")
-		return
-	}
-	bucket := "voltage-" + environment + "-system"
-	hostname, err := os.Hostname()
-	if err != nil {
-		hostname = "unknown"
+// RotateFileSealed rotates the active log file the same way RotateFile does,
+// but returns the path the previous file was sealed under instead of
+// discarding it. It is used by the LogShipper subsystem so that a file is
+// always fully closed and renamed before it is handed off for upload -
+// there is no window where a shipper could read a half-written file.
+// If the log isn't backed by a file then it returns an empty path and a nil
+// error.
+func RotateFileSealed() (string, error) {
+	if logFile == nil && logFilePath == "" {
+		return "", nil
 	}
-	s3path := "traefik-logs/" + hostname + "/traefik.log"
-
-	session := session.Must(session.NewSession(aws.NewConfig().WithRegion("us-west-2")))
-
-	ticker := time.NewTicker(10 * time.Minute)
-	quit := make(chan struct{})
-	go func() {
-		for {
-			select {
-			case <-ticker.C:
-				file, err := os.Open(logFilePath)
-				if err != nil {
-					fmt.Println(err)
-					continue
-				}
-				defer file.Close()
-				upParams := &s3manager.UploadInput{
-					Bucket: &bucket,
-					Key:    &s3path,
-					Body:   file,
-				}
-				uploader := s3manager.NewUploader(session)
-				_, err = uploader.Upload(upParams)
-				if err != nil {
-					fmt.Println(err)
-					continue
-				}
-			case <-quit:
-				ticker.Stop()
-				return
-			}
+
+	sealedPath := logFilePath + "-" + time.Now().UTC().Format("20060102-150405.000000000")
+
+	if logFile != nil {
+		if err := logFile.Close(); err != nil {
+			return "", fmt.Errorf("closing log file: %w", err)
 		}
-	}()
+	}
+
+	if err := os.Rename(logFilePath, sealedPath); err != nil {
+		return "", fmt.Errorf("sealing log file: %w", err)
+	}
+
+	if err := OpenFile(logFilePath); err != nil {
+		return "", fmt.Errorf("error opening log file: %w", err)
+	}
+
+	return sealedPath, nil
 }
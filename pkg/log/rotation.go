@@ -0,0 +1,286 @@
+package log
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// RotationConfig configures Traefik's internal log rotation, as an
+// alternative to relying on an external rotator (e.g. logrotate) plus a
+// RotateFile signal.
+type RotationConfig struct {
+	// MaxSizeMB rotates the file once it grows past this size.
+	MaxSizeMB int `description:"Maximum log file size in MB before rotation" json:"maxSizeMB,omitempty" toml:"maxSizeMB,omitempty" yaml:"maxSizeMB,omitempty" export:"true"`
+	// MaxAgeDays deletes rotated backups older than this many days. Zero
+	// disables age-based deletion.
+	MaxAgeDays int `description:"Maximum age in days of a rotated backup before it is deleted" json:"maxAgeDays,omitempty" toml:"maxAgeDays,omitempty" yaml:"maxAgeDays,omitempty" export:"true"`
+	// MaxBackups caps the number of rotated backups kept on disk. Zero
+	// keeps them all (subject to MaxAgeDays).
+	MaxBackups int `description:"Maximum number of rotated backups to retain" json:"maxBackups,omitempty" toml:"maxBackups,omitempty" yaml:"maxBackups,omitempty" export:"true"`
+	// Compress selects the compression applied to a rotated backup.
+	Compress CompressionType `description:"Compression applied to rotated backups (none, gzip, zstd)" json:"compress,omitempty" toml:"compress,omitempty" yaml:"compress,omitempty" export:"true"`
+	// LocalTime timestamps backups using local time instead of UTC.
+	LocalTime bool `description:"Timestamp backups using local time instead of UTC" json:"localTime,omitempty" toml:"localTime,omitempty" yaml:"localTime,omitempty" export:"true"`
+	// RotateOnStart forces one rotation as soon as the writer starts, so
+	// each process run gets its own backup.
+	RotateOnStart bool `description:"Rotate once immediately on startup" json:"rotateOnStart,omitempty" toml:"rotateOnStart,omitempty" yaml:"rotateOnStart,omitempty" export:"true"`
+}
+
+// RotatingWriter is an io.Writer over a file that rotates itself according
+// to a RotationConfig, without requiring SIGHUP or an external rotator.
+//
+// Writers never contend on a lock shared with rotation: the active
+// destination is held in an atomic.Pointer and a write reads it once with
+// Load. Rotation swaps that pointer to a freshly opened file; writes
+// already in flight against the old file are tracked by a per-file
+// inflight counter, and Rotate waits for that counter to drain to zero
+// before closing the old file, so a write that loaded the old fileRef
+// just before a concurrent Rotate still completes against a live file
+// descriptor instead of failing with "file already closed". Writes that
+// start after the swap simply see the new fileRef.
+//
+// Rotate itself can be called concurrently - from the size-triggered
+// goroutine in Write, from WatchSignal's SIGUSR1 handler, and from
+// WatchSchedule's timer all at once - so its rename sequence is guarded by
+// rotateMu. Without that, two overlapping renames race over the same
+// backup/tmp path and fail with "no such file or directory".
+type RotatingWriter struct {
+	path    string
+	cfg     RotationConfig
+	shipper LogShipper
+
+	current atomic.Pointer[fileRef]
+	size    atomic.Int64
+
+	rotateMu sync.Mutex
+}
+
+// fileRef pairs an open file with a count of writes currently in flight
+// against it, so Rotate knows when it's safe to close.
+type fileRef struct {
+	file     *os.File
+	inflight atomic.Int64
+}
+
+// NewRotatingWriter opens path and returns a RotatingWriter governed by
+// cfg. shipper may be nil, in which case sealed backups are kept on disk
+// for retention/cleanup only and are never uploaded.
+func NewRotatingWriter(path string, cfg RotationConfig, shipper LogShipper) (*RotatingWriter, error) {
+	w := &RotatingWriter{
+		path:    path,
+		cfg:     cfg,
+		shipper: shipper,
+	}
+
+	file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE|os.O_APPEND, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("opening %s: %w", path, err)
+	}
+
+	if info, err := file.Stat(); err == nil {
+		w.size.Store(info.Size())
+	}
+
+	w.current.Store(&fileRef{file: file})
+
+	if cfg.RotateOnStart {
+		if err := w.Rotate(); err != nil {
+			return nil, fmt.Errorf("rotating on start: %w", err)
+		}
+	}
+
+	return w, nil
+}
+
+// Write implements io.Writer. It never blocks on rotation: it writes to
+// whichever file is current at the moment it reads the pointer, and
+// triggers an asynchronous rotation once the size threshold is crossed.
+func (w *RotatingWriter) Write(p []byte) (int, error) {
+	// Re-check the pointer after registering as in flight: if Rotate
+	// swapped it in between, its drain may already have finished, so retry
+	// against the new file instead of writing to one about to be closed.
+	var ref *fileRef
+	for {
+		ref = w.current.Load()
+		ref.inflight.Add(1)
+		if w.current.Load() == ref {
+			break
+		}
+		ref.inflight.Add(-1)
+	}
+	defer ref.inflight.Add(-1)
+
+	n, err := ref.file.Write(p)
+	if err != nil {
+		return n, fmt.Errorf("writing to %s: %w", w.path, err)
+	}
+
+	newSize := w.size.Add(int64(n))
+
+	if w.cfg.MaxSizeMB > 0 && newSize >= int64(w.cfg.MaxSizeMB)*1024*1024 {
+		go func() {
+			if err := w.Rotate(); err != nil {
+				WithoutContext().WithError(err).Error("Log rotation failed")
+			}
+		}()
+	}
+
+	return n, nil
+}
+
+// Rotate seals the active file under a timestamped backup name, swaps in a
+// freshly opened file, and compresses the backup. With a shipper it then
+// ships the backup and deletes it locally; without one it applies the
+// retention policy instead. It is safe to call concurrently and safe to call from
+// a signal handler's goroutine or a scheduled tick.
+func (w *RotatingWriter) Rotate() error {
+	w.rotateMu.Lock()
+	defer w.rotateMu.Unlock()
+
+	backupPath := w.backupPath()
+
+	newFile, err := os.OpenFile(w.path+".tmp-rotate", os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0o644)
+	if err != nil {
+		return fmt.Errorf("creating replacement log file: %w", err)
+	}
+
+	if err := os.Rename(w.path, backupPath); err != nil {
+		newFile.Close()
+		os.Remove(w.path + ".tmp-rotate")
+		return fmt.Errorf("sealing %s as %s: %w", w.path, backupPath, err)
+	}
+
+	if err := os.Rename(w.path+".tmp-rotate", w.path); err != nil {
+		newFile.Close()
+		return fmt.Errorf("installing replacement log file: %w", err)
+	}
+
+	old := w.current.Swap(&fileRef{file: newFile})
+	w.size.Store(0)
+
+	drainFileRef(old)
+
+	if err := old.file.Close(); err != nil {
+		return fmt.Errorf("closing sealed log file: %w", err)
+	}
+
+	return w.finishBackup(backupPath)
+}
+
+// drainFileRef waits for writes already in flight against ref to finish
+// before the caller closes its file. Individual writes are a single
+// syscall, so this is expected to return almost immediately; it gives up
+// after a grace period so a stuck write can never wedge rotation forever.
+func drainFileRef(ref *fileRef) {
+	const (
+		pollInterval = time.Millisecond
+		graceTimeout = 2 * time.Second
+	)
+
+	deadline := time.Now().Add(graceTimeout)
+	for ref.inflight.Load() > 0 {
+		if time.Now().After(deadline) {
+			WithoutContext().Warn("Log rotation timed out waiting for in-flight writes; closing file anyway")
+			return
+		}
+		time.Sleep(pollInterval)
+	}
+}
+
+func (w *RotatingWriter) finishBackup(backupPath string) error {
+	finalPath, err := compressFile(backupPath, w.cfg.Compress)
+	if err != nil {
+		return fmt.Errorf("compressing %s: %w", backupPath, err)
+	}
+
+	if w.shipper != nil {
+		if err := w.shipper.Ship(context.Background(), finalPath); err != nil {
+			return fmt.Errorf("shipping %s: %w", finalPath, err)
+		}
+		return os.Remove(finalPath)
+	}
+
+	return w.applyRetention()
+}
+
+func (w *RotatingWriter) backupPath() string {
+	now := time.Now()
+	if !w.cfg.LocalTime {
+		now = now.UTC()
+	}
+
+	return w.path + "-" + now.Format("2006-01-02T15-04-05.000000000")
+}
+
+// applyRetention enforces MaxBackups and MaxAgeDays against the backups
+// sitting next to the active log file.
+func (w *RotatingWriter) applyRetention() error {
+	backups, err := w.listBackups()
+	if err != nil {
+		return fmt.Errorf("listing backups: %w", err)
+	}
+
+	if w.cfg.MaxAgeDays > 0 {
+		cutoff := time.Now().AddDate(0, 0, -w.cfg.MaxAgeDays)
+		var kept []backupFile
+		for _, b := range backups {
+			if b.modTime.Before(cutoff) {
+				if err := os.Remove(b.path); err != nil {
+					return fmt.Errorf("removing expired backup %s: %w", b.path, err)
+				}
+				continue
+			}
+			kept = append(kept, b)
+		}
+		backups = kept
+	}
+
+	if w.cfg.MaxBackups > 0 && len(backups) > w.cfg.MaxBackups {
+		sort.Slice(backups, func(i, j int) bool { return backups[i].modTime.Before(backups[j].modTime) })
+		for _, b := range backups[:len(backups)-w.cfg.MaxBackups] {
+			if err := os.Remove(b.path); err != nil {
+				return fmt.Errorf("removing excess backup %s: %w", b.path, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+type backupFile struct {
+	path    string
+	modTime time.Time
+}
+
+func (w *RotatingWriter) listBackups() ([]backupFile, error) {
+	dir := filepath.Dir(w.path)
+	prefix := filepath.Base(w.path) + "-"
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var backups []backupFile
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasPrefix(entry.Name(), prefix) {
+			continue
+		}
+
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+
+		backups = append(backups, backupFile{path: filepath.Join(dir, entry.Name()), modTime: info.ModTime()})
+	}
+
+	return backups, nil
+}
@@ -0,0 +1,127 @@
+package log
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// WatchSignal rotates w every time the process receives SIGUSR1, which
+// lets an operator force a rotation without logrotate and without
+// restarting Traefik. It returns a stop function that stops watching.
+func (w *RotatingWriter) WatchSignal(ctx context.Context) (stop func(), err error) {
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, syscall.SIGUSR1)
+
+	done := make(chan struct{})
+	stopCh := make(chan struct{})
+
+	go func() {
+		defer close(done)
+
+		logger := FromContext(ctx)
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-stopCh:
+				return
+			case <-sigCh:
+				if err := w.Rotate(); err != nil {
+					logger.WithError(err).Error("SIGUSR1-triggered log rotation failed")
+				}
+			}
+		}
+	}()
+
+	return func() {
+		signal.Stop(sigCh)
+		close(stopCh)
+		<-done
+	}, nil
+}
+
+// WatchSchedule rotates w every time the clock crosses the time of day
+// described by at (an "HH:MM" string, e.g. "00:00" for daily at midnight).
+// The time of day is interpreted in UTC unless cfg.LocalTime is set. It
+// returns a stop function that stops the schedule.
+func (w *RotatingWriter) WatchSchedule(ctx context.Context, at string) (stop func(), err error) {
+	hour, minute, err := parseTimeOfDay(at)
+	if err != nil {
+		return nil, fmt.Errorf("parsing rotation schedule %q: %w", at, err)
+	}
+
+	done := make(chan struct{})
+	stopCh := make(chan struct{})
+
+	go func() {
+		defer close(done)
+
+		logger := FromContext(ctx)
+
+		for {
+			timer := time.NewTimer(durationUntil(hour, minute, w.cfg.LocalTime))
+
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return
+			case <-stopCh:
+				timer.Stop()
+				return
+			case <-timer.C:
+				if err := w.Rotate(); err != nil {
+					logger.WithError(err).Error("Scheduled log rotation failed")
+				}
+			}
+		}
+	}()
+
+	return func() {
+		close(stopCh)
+		<-done
+	}, nil
+}
+
+// parseTimeOfDay parses an "HH:MM" string into its hour and minute
+// components.
+func parseTimeOfDay(at string) (hour, minute int, err error) {
+	parts := strings.SplitN(at, ":", 2)
+	if len(parts) != 2 {
+		return 0, 0, fmt.Errorf(`expected "HH:MM", got %q`, at)
+	}
+
+	hour, err = strconv.Atoi(parts[0])
+	if err != nil || hour < 0 || hour > 23 {
+		return 0, 0, fmt.Errorf("invalid hour in %q", at)
+	}
+
+	minute, err = strconv.Atoi(parts[1])
+	if err != nil || minute < 0 || minute > 59 {
+		return 0, 0, fmt.Errorf("invalid minute in %q", at)
+	}
+
+	return hour, minute, nil
+}
+
+// durationUntil returns the time remaining until the next occurrence of
+// hour:minute, today if it hasn't passed yet, tomorrow otherwise.
+func durationUntil(hour, minute int, localTime bool) time.Duration {
+	now := time.Now()
+	if !localTime {
+		now = now.UTC()
+	}
+
+	next := time.Date(now.Year(), now.Month(), now.Day(), hour, minute, 0, 0, now.Location())
+	if !next.After(now) {
+		next = next.AddDate(0, 0, 1)
+	}
+
+	return next.Sub(now)
+}
@@ -0,0 +1,72 @@
+package log
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func writeBackup(t *testing.T, path string, age time.Duration) {
+	t.Helper()
+
+	if err := os.WriteFile(path, []byte("line\n"), 0o644); err != nil {
+		t.Fatalf("writing backup %s: %v", path, err)
+	}
+
+	modTime := time.Now().Add(-age)
+	if err := os.Chtimes(path, modTime, modTime); err != nil {
+		t.Fatalf("setting mtime on %s: %v", path, err)
+	}
+}
+
+func TestApplyRetentionMaxBackups(t *testing.T) {
+	dir := t.TempDir()
+	base := filepath.Join(dir, "traefik.log")
+
+	suffixes := []string{"a", "b", "c"}
+	for i, age := range []time.Duration{3 * time.Hour, 2 * time.Hour, time.Hour} {
+		writeBackup(t, base+"-"+suffixes[i], age)
+	}
+
+	w := &RotatingWriter{path: base, cfg: RotationConfig{MaxBackups: 1}}
+
+	if err := w.applyRetention(); err != nil {
+		t.Fatalf("applyRetention: %v", err)
+	}
+
+	backups, err := w.listBackups()
+	if err != nil {
+		t.Fatalf("listBackups: %v", err)
+	}
+
+	if len(backups) != 1 {
+		t.Fatalf("len(backups) = %d, want 1", len(backups))
+	}
+	if backups[0].modTime.Before(time.Now().Add(-90 * time.Minute)) {
+		t.Fatalf("the retained backup should be the most recent one, got mtime %v", backups[0].modTime)
+	}
+}
+
+func TestApplyRetentionMaxAgeDays(t *testing.T) {
+	dir := t.TempDir()
+	base := filepath.Join(dir, "traefik.log")
+
+	oldPath := base + "-old"
+	newPath := base + "-new"
+	writeBackup(t, oldPath, 48*time.Hour)
+	writeBackup(t, newPath, time.Hour)
+
+	w := &RotatingWriter{path: base, cfg: RotationConfig{MaxAgeDays: 1}}
+
+	if err := w.applyRetention(); err != nil {
+		t.Fatalf("applyRetention: %v", err)
+	}
+
+	if _, err := os.Stat(oldPath); !os.IsNotExist(err) {
+		t.Fatalf("expected expired backup %s to be removed, stat err = %v", oldPath, err)
+	}
+	if _, err := os.Stat(newPath); err != nil {
+		t.Fatalf("expected recent backup %s to survive, stat err = %v", newPath, err)
+	}
+}
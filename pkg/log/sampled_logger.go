@@ -0,0 +1,148 @@
+package log
+
+import (
+	"context"
+
+	"github.com/sirupsen/logrus"
+)
+
+type samplerKey contextKey
+
+const samplerContextKey samplerKey = 0
+
+// WithSampler attaches spec to ctx so that the logger FromContext returns
+// for it (and for any context derived from it before the next WithFields
+// call) samples according to spec. This lets a middleware override
+// sampling per-router, e.g. always logging 5xx responses in full while
+// sampling 2xx at 1%.
+func WithSampler(ctx context.Context, spec SamplerSpec) context.Context {
+	sampler := NewSampler(spec)
+
+	logger := &sampledLogger{Entry: toEntry(FromContext(ctx)), sampler: sampler}
+
+	ctx = context.WithValue(ctx, samplerContextKey, sampler)
+	return context.WithValue(ctx, loggerKey, logger)
+}
+
+// SamplerFromContext returns the Sampler attached to ctx via WithSampler,
+// or nil if none was attached.
+func SamplerFromContext(ctx context.Context) *Sampler {
+	sampler, _ := ctx.Value(samplerContextKey).(*Sampler)
+	return sampler
+}
+
+// toEntry unwraps logger to the *logrus.Entry backing it, so a sampler can
+// be layered on top of whatever FromContext previously returned.
+func toEntry(logger Logger) *logrus.Entry {
+	switch l := logger.(type) {
+	case *logrus.Entry:
+		return l
+	case *sampledLogger:
+		return l.Entry
+	default:
+		return logrus.NewEntry(logrus.StandardLogger())
+	}
+}
+
+// sampledLogger decorates a *logrus.Entry with a Sampler: every Debug/Info/
+// Warn/Error variant - plain, ...ln, and the printf-style ...f used by most
+// call sites in this codebase - consults the sampler before delegating.
+// WithField, WithFields and WithError pass straight through via the
+// embedded Entry, since they don't emit a record themselves.
+//
+// Fatal* and Panic* are deliberately left unsampled: they terminate the
+// process or unwind the goroutine, so dropping one would hide the reason
+// Traefik is about to exit or panic - exactly the information a sampler
+// should never be allowed to discard.
+type sampledLogger struct {
+	*logrus.Entry
+	sampler *Sampler
+}
+
+func (l *sampledLogger) Debug(args ...interface{}) {
+	if l.sampler.Allow(logrus.DebugLevel) {
+		l.Entry.Debug(args...)
+	}
+}
+
+func (l *sampledLogger) Debugln(args ...interface{}) {
+	if l.sampler.Allow(logrus.DebugLevel) {
+		l.Entry.Debugln(args...)
+	}
+}
+
+func (l *sampledLogger) Debugf(format string, args ...interface{}) {
+	if l.sampler.Allow(logrus.DebugLevel) {
+		l.Entry.Debugf(format, args...)
+	}
+}
+
+func (l *sampledLogger) Info(args ...interface{}) {
+	if l.sampler.Allow(logrus.InfoLevel) {
+		l.Entry.Info(args...)
+	}
+}
+
+func (l *sampledLogger) Infoln(args ...interface{}) {
+	if l.sampler.Allow(logrus.InfoLevel) {
+		l.Entry.Infoln(args...)
+	}
+}
+
+func (l *sampledLogger) Infof(format string, args ...interface{}) {
+	if l.sampler.Allow(logrus.InfoLevel) {
+		l.Entry.Infof(format, args...)
+	}
+}
+
+func (l *sampledLogger) Warn(args ...interface{}) {
+	if l.sampler.Allow(logrus.WarnLevel) {
+		l.Entry.Warn(args...)
+	}
+}
+
+func (l *sampledLogger) Warnln(args ...interface{}) {
+	if l.sampler.Allow(logrus.WarnLevel) {
+		l.Entry.Warnln(args...)
+	}
+}
+
+func (l *sampledLogger) Warnf(format string, args ...interface{}) {
+	if l.sampler.Allow(logrus.WarnLevel) {
+		l.Entry.Warnf(format, args...)
+	}
+}
+
+// Warning, Warningln and Warningf are logrus's aliases for Warn/Warnln/
+// Warnf. They need their own overrides: the embedded *logrus.Entry's
+// Warning methods call entry.Warn internally on themselves, not through
+// this wrapper, so without these they would silently bypass the sampler.
+func (l *sampledLogger) Warning(args ...interface{}) {
+	l.Warn(args...)
+}
+
+func (l *sampledLogger) Warningln(args ...interface{}) {
+	l.Warnln(args...)
+}
+
+func (l *sampledLogger) Warningf(format string, args ...interface{}) {
+	l.Warnf(format, args...)
+}
+
+func (l *sampledLogger) Error(args ...interface{}) {
+	if l.sampler.Allow(logrus.ErrorLevel) {
+		l.Entry.Error(args...)
+	}
+}
+
+func (l *sampledLogger) Errorln(args ...interface{}) {
+	if l.sampler.Allow(logrus.ErrorLevel) {
+		l.Entry.Errorln(args...)
+	}
+}
+
+func (l *sampledLogger) Errorf(format string, args ...interface{}) {
+	if l.sampler.Allow(logrus.ErrorLevel) {
+		l.Entry.Errorf(format, args...)
+	}
+}
@@ -0,0 +1,230 @@
+package log
+
+import (
+	"math/rand"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// BurstSpec describes a "let the first N lines through, then sample the
+// rest" policy for a single level, so a log storm during an outage doesn't
+// drown the disk while the first few lines of the burst (which usually
+// carry the most useful signal) still get through in full.
+type BurstSpec struct {
+	// Level is the level this policy applies to, typically ErrorLevel.
+	Level logrus.Level
+	// Full is how many consecutive lines at Level pass through
+	// unsampled once a burst starts.
+	Full int
+	// Decay is the sampling probability applied to lines past Full,
+	// in [0,1].
+	Decay float64
+	// Reset is how long the level must stay quiet before the burst
+	// window closes and a fresh burst gets its Full lines again.
+	Reset time.Duration
+}
+
+// SamplerSpec configures a Sampler. A zero value samples everything (every
+// line passes through unsampled), so callers should only set the levels
+// they want to limit and leave the rest unset to let them through as-is -
+// NewSampler treats an absent entry in RateLimit/Probability as
+// "unlimited" rather than "blocked".
+type SamplerSpec struct {
+	// RateLimit caps each level to N log lines per second via a token
+	// bucket. Levels not present here are not rate limited.
+	RateLimit map[logrus.Level]float64
+	// Probability randomly keeps a fraction of lines at each level,
+	// typically used for DebugLevel/TraceLevel (e.g. 0.01 keeps 1%).
+	// Levels not present here are always kept.
+	Probability map[logrus.Level]float64
+	// Burst optionally overrides RateLimit/Probability for one level
+	// with burst-then-decay behavior.
+	Burst *BurstSpec
+	// SummaryInterval controls how often a "sampled=N, dropped=M"
+	// summary record is emitted per level. Defaults to 30s.
+	SummaryInterval time.Duration
+}
+
+// levelStats accumulates per-level sampling outcomes between summaries.
+type levelStats struct {
+	sampled atomic.Uint64
+	dropped atomic.Uint64
+}
+
+// Sampler decides, per log level, whether a given line should reach the
+// underlying logger. It is cheap to call on the hot path: the common case
+// is a single token-bucket decrement or a single rand.Float64 comparison.
+type Sampler struct {
+	spec    SamplerSpec
+	buckets map[logrus.Level]*tokenBucket
+	stats   map[logrus.Level]*levelStats
+
+	burstMu    sync.Mutex
+	burstCount int
+	burstLast  time.Time
+
+	stopSummary func()
+}
+
+// NewSampler builds a Sampler from spec and starts its periodic summary
+// goroutine. Callers should call Close when the sampler is no longer in
+// use to stop that goroutine.
+func NewSampler(spec SamplerSpec) *Sampler {
+	s := &Sampler{
+		spec:    spec,
+		buckets: make(map[logrus.Level]*tokenBucket, len(spec.RateLimit)),
+		stats:   make(map[logrus.Level]*levelStats),
+	}
+
+	for level, rate := range spec.RateLimit {
+		s.buckets[level] = newTokenBucket(rate)
+	}
+
+	for _, level := range logrus.AllLevels {
+		s.stats[level] = &levelStats{}
+	}
+
+	interval := spec.SummaryInterval
+	if interval <= 0 {
+		interval = 30 * time.Second
+	}
+
+	stop := make(chan struct{})
+	go s.runSummary(interval, stop)
+	s.stopSummary = sync.OnceFunc(func() { close(stop) })
+
+	return s
+}
+
+// Close stops the periodic summary goroutine.
+func (s *Sampler) Close() {
+	if s.stopSummary != nil {
+		s.stopSummary()
+	}
+}
+
+// Allow reports whether a line at level should reach the underlying
+// logger. It records the outcome for the next summary record regardless
+// of the decision.
+func (s *Sampler) Allow(level logrus.Level) bool {
+	allowed := s.decide(level)
+
+	if stats, ok := s.stats[level]; ok {
+		if allowed {
+			stats.sampled.Add(1)
+		} else {
+			stats.dropped.Add(1)
+		}
+	}
+
+	return allowed
+}
+
+func (s *Sampler) decide(level logrus.Level) bool {
+	if s.spec.Burst != nil && level == s.spec.Burst.Level {
+		return s.decideBurst(*s.spec.Burst)
+	}
+
+	if bucket, ok := s.buckets[level]; ok && !bucket.Allow() {
+		return false
+	}
+
+	if prob, ok := s.spec.Probability[level]; ok {
+		return rand.Float64() < prob
+	}
+
+	return true
+}
+
+func (s *Sampler) decideBurst(spec BurstSpec) bool {
+	s.burstMu.Lock()
+	defer s.burstMu.Unlock()
+
+	now := time.Now()
+	if spec.Reset > 0 && !s.burstLast.IsZero() && now.Sub(s.burstLast) > spec.Reset {
+		s.burstCount = 0
+	}
+	s.burstLast = now
+
+	s.burstCount++
+	if s.burstCount <= spec.Full {
+		return true
+	}
+
+	return rand.Float64() < spec.Decay
+}
+
+func (s *Sampler) runSummary(interval time.Duration, stop chan struct{}) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			s.emitSummary()
+		}
+	}
+}
+
+func (s *Sampler) emitSummary() {
+	for level, stats := range s.stats {
+		sampled := stats.sampled.Swap(0)
+		dropped := stats.dropped.Swap(0)
+		if sampled == 0 && dropped == 0 {
+			continue
+		}
+
+		WithoutContext().WithFields(logrus.Fields{
+			"sampled": sampled,
+			"dropped": dropped,
+			"level":   level.String(),
+		}).Info("Log sampling summary")
+	}
+}
+
+// tokenBucket is a classic token bucket: tokens accrue at rate per second
+// up to a one-second burst capacity, and Allow consumes one token.
+type tokenBucket struct {
+	mu     sync.Mutex
+	rate   float64
+	tokens float64
+	last   time.Time
+}
+
+func newTokenBucket(rate float64) *tokenBucket {
+	return &tokenBucket{
+		rate:   rate,
+		tokens: rate,
+		last:   time.Now(),
+	}
+}
+
+func (b *tokenBucket) Allow() bool {
+	if b.rate <= 0 {
+		return false
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := time.Now()
+	elapsed := now.Sub(b.last).Seconds()
+	b.last = now
+
+	b.tokens += elapsed * b.rate
+	if b.tokens > b.rate {
+		b.tokens = b.rate
+	}
+
+	if b.tokens < 1 {
+		return false
+	}
+
+	b.tokens--
+	return true
+}
@@ -0,0 +1,94 @@
+package log
+
+import (
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+func TestTokenBucketAllowsUpToRateThenBlocks(t *testing.T) {
+	b := newTokenBucket(5)
+
+	allowed := 0
+	for i := 0; i < 10; i++ {
+		if b.Allow() {
+			allowed++
+		}
+	}
+
+	if allowed != 5 {
+		t.Fatalf("allowed = %d, want 5 (rate is the initial burst capacity)", allowed)
+	}
+
+	if b.Allow() {
+		t.Fatal("bucket should be empty immediately after draining its capacity")
+	}
+}
+
+func TestTokenBucketRefillsOverTime(t *testing.T) {
+	b := newTokenBucket(100)
+	for b.Allow() {
+	}
+
+	b.last = time.Now().Add(-500 * time.Millisecond)
+
+	if !b.Allow() {
+		t.Fatal("bucket should have refilled roughly half its rate after 500ms")
+	}
+}
+
+func TestSamplerBurstThenDecay(t *testing.T) {
+	s := NewSampler(SamplerSpec{
+		Burst: &BurstSpec{
+			Level: logrus.ErrorLevel,
+			Full:  3,
+			Decay: 0, // deterministic: fully dropped once the burst is exhausted.
+			Reset: time.Minute,
+		},
+	})
+	defer s.Close()
+
+	for i := 0; i < 3; i++ {
+		if !s.Allow(logrus.ErrorLevel) {
+			t.Fatalf("line %d should pass through during the initial burst", i)
+		}
+	}
+
+	if s.Allow(logrus.ErrorLevel) {
+		t.Fatal("line past the burst window should be dropped when Decay is 0")
+	}
+}
+
+func TestSamplerProbabilityZeroDropsEverything(t *testing.T) {
+	s := NewSampler(SamplerSpec{
+		Probability: map[logrus.Level]float64{logrus.DebugLevel: 0},
+	})
+	defer s.Close()
+
+	for i := 0; i < 20; i++ {
+		if s.Allow(logrus.DebugLevel) {
+			t.Fatal("probability 0 must never let a line through")
+		}
+	}
+}
+
+func TestSampledLoggerPrintfVariantsAreSampled(t *testing.T) {
+	s := NewSampler(SamplerSpec{
+		Probability: map[logrus.Level]float64{logrus.InfoLevel: 0},
+	})
+	defer s.Close()
+
+	logger := &sampledLogger{Entry: logrus.NewEntry(logrus.StandardLogger()), sampler: s}
+
+	// None of these should panic; with probability 0 they must all be
+	// no-ops rather than falling through unsampled to the embedded Entry.
+	logger.Infof("request %d", 1)
+	logger.Info("request")
+	logger.Infoln("request")
+
+	stats := s.stats[logrus.InfoLevel]
+	if got := stats.dropped.Load(); got != 3 {
+		t.Fatalf("dropped = %d, want 3 (Infof must consult the sampler like Info/Infoln)", got)
+	}
+}
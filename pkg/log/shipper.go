@@ -0,0 +1,260 @@
+package log
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// ShipperType identifies a LogShipper implementation.
+type ShipperType string
+
+const (
+	// ShipperTypeS3 ships logs to an Amazon S3 (or S3-compatible) bucket.
+	ShipperTypeS3 ShipperType = "s3"
+	// ShipperTypeGCS ships logs to a Google Cloud Storage bucket.
+	ShipperTypeGCS ShipperType = "gcs"
+	// ShipperTypeAzure ships logs to an Azure Blob Storage container.
+	ShipperTypeAzure ShipperType = "azure"
+	// ShipperTypeLoki pushes logs to a Grafana Loki instance over HTTP.
+	ShipperTypeLoki ShipperType = "loki"
+	// ShipperTypeSyslog forwards logs to a syslog collector, using the
+	// BSD-style header of the standard library's log/syslog (not RFC 5424).
+	ShipperTypeSyslog ShipperType = "syslog"
+	// ShipperTypeHTTP posts logs to a generic HTTP endpoint.
+	ShipperTypeHTTP ShipperType = "http"
+)
+
+// CompressionType selects how a file is compressed before it is shipped.
+type CompressionType string
+
+const (
+	// CompressionNone ships the file as-is.
+	CompressionNone CompressionType = "none"
+	// CompressionGzip compresses the file with gzip before shipping.
+	CompressionGzip CompressionType = "gzip"
+	// CompressionZstd compresses the file with zstd before shipping.
+	CompressionZstd CompressionType = "zstd"
+)
+
+// LogShipper ships a sealed log file to a remote destination.
+type LogShipper interface {
+	// Ship uploads the file at path. Implementations must treat path as
+	// read-only and must not delete or modify it.
+	Ship(ctx context.Context, path string) error
+	// Close releases any resources held by the shipper (connections,
+	// credentials, background goroutines).
+	Close() error
+}
+
+// ShippingConfig configures the LogShipper subsystem.
+type ShippingConfig struct {
+	// Type selects which LogShipper implementation to instantiate.
+	// Allowed values: s3, gcs, azure, loki, syslog, http.
+	Type ShipperType `description:"Log shipping destination type" json:"type,omitempty" toml:"type,omitempty" yaml:"type,omitempty" export:"true"`
+
+	// Interval is how often the active log file is rotated and shipped.
+	Interval time.Duration `description:"Interval between log shipments" json:"interval,omitempty" toml:"interval,omitempty" yaml:"interval,omitempty" export:"true"`
+	// MaxSizeMB rotates and ships the file early if it grows past this size.
+	MaxSizeMB int `description:"Maximum log file size in MB before an early rotation and shipment" json:"maxSizeMB,omitempty" toml:"maxSizeMB,omitempty" yaml:"maxSizeMB,omitempty" export:"true"`
+	// Compression selects the compression applied before shipping.
+	Compression CompressionType `description:"Compression applied to the file before shipping (none, gzip, zstd)" json:"compression,omitempty" toml:"compression,omitempty" yaml:"compression,omitempty" export:"true"`
+
+	S3     *S3ShipperConfig     `description:"Settings for the S3 shipper" json:"s3,omitempty" toml:"s3,omitempty" yaml:"s3,omitempty" export:"true"`
+	GCS    *GCSShipperConfig    `description:"Settings for the GCS shipper" json:"gcs,omitempty" toml:"gcs,omitempty" yaml:"gcs,omitempty" export:"true"`
+	Azure  *AzureShipperConfig  `description:"Settings for the Azure Blob shipper" json:"azure,omitempty" toml:"azure,omitempty" yaml:"azure,omitempty" export:"true"`
+	Loki   *LokiShipperConfig   `description:"Settings for the Loki shipper" json:"loki,omitempty" toml:"loki,omitempty" yaml:"loki,omitempty" export:"true"`
+	Syslog *SyslogShipperConfig `description:"Settings for the syslog shipper" json:"syslog,omitempty" toml:"syslog,omitempty" yaml:"syslog,omitempty" export:"true"`
+	HTTP   *HTTPShipperConfig   `description:"Settings for the generic HTTP shipper" json:"http,omitempty" toml:"http,omitempty" yaml:"http,omitempty" export:"true"`
+}
+
+// SetDefaults sets the default values for a ShippingConfig.
+func (c *ShippingConfig) SetDefaults() {
+	c.Interval = 10 * time.Minute
+	c.Compression = CompressionGzip
+}
+
+// NewShipper builds the LogShipper implementation selected by cfg.Type.
+func NewShipper(cfg *ShippingConfig) (LogShipper, error) {
+	if cfg == nil {
+		return nil, fmt.Errorf("log shipping config is required")
+	}
+
+	switch cfg.Type {
+	case ShipperTypeS3:
+		return newS3Shipper(cfg.S3)
+	case ShipperTypeGCS:
+		return newGCSShipper(cfg.GCS)
+	case ShipperTypeAzure:
+		return newAzureShipper(cfg.Azure)
+	case ShipperTypeLoki:
+		return newLokiShipper(cfg.Loki)
+	case ShipperTypeSyslog:
+		return newSyslogShipper(cfg.Syslog)
+	case ShipperTypeHTTP:
+		return newHTTPShipper(cfg.HTTP)
+	default:
+		return nil, fmt.Errorf("unsupported log shipper type: %q", cfg.Type)
+	}
+}
+
+// sizeCheckInterval is how often Start polls the active log file's size
+// against cfg.MaxSizeMB, independently of the regular shipping interval.
+const sizeCheckInterval = 15 * time.Second
+
+// ShipperManager drives the periodic rotate-seal-ship cycle for a LogShipper.
+// Its owner runs it with Start and ends it with Stop; this package does not
+// start one by itself.
+type ShipperManager struct {
+	shipper LogShipper
+	cfg     *ShippingConfig
+
+	cancel context.CancelFunc
+	done   chan struct{}
+
+	mu      sync.Mutex
+	started bool
+}
+
+// NewShipperManager creates a ShipperManager for the given shipper. cfg's
+// Interval defaults to 10 minutes when zero or negative; its MaxSizeMB and
+// Compression fields are honored by Start.
+func NewShipperManager(shipper LogShipper, cfg *ShippingConfig) *ShipperManager {
+	if cfg == nil {
+		cfg = &ShippingConfig{}
+	}
+	if cfg.Interval <= 0 {
+		cfg.Interval = 10 * time.Minute
+	}
+
+	return &ShipperManager{
+		shipper: shipper,
+		cfg:     cfg,
+		done:    make(chan struct{}),
+	}
+}
+
+// Start begins the periodic rotate-seal-ship loop. It returns immediately;
+// the loop runs in a background goroutine until ctx is canceled or Stop is
+// called. In addition to the fixed Interval, the active log file's size is
+// polled every sizeCheckInterval so that MaxSizeMB can trigger an early
+// rotation and shipment without waiting for the next scheduled tick.
+func (m *ShipperManager) Start(ctx context.Context) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.started {
+		return fmt.Errorf("shipper manager already started")
+	}
+	m.started = true
+
+	ctx, cancel := context.WithCancel(ctx)
+	m.cancel = cancel
+
+	logger := FromContext(ctx)
+
+	go func() {
+		defer close(m.done)
+
+		ticker := time.NewTicker(m.cfg.Interval)
+		defer ticker.Stop()
+
+		var sizeTicker *time.Ticker
+		var sizeTickerC <-chan time.Time
+		if m.cfg.MaxSizeMB > 0 {
+			sizeTicker = time.NewTicker(sizeCheckInterval)
+			defer sizeTicker.Stop()
+			sizeTickerC = sizeTicker.C
+		}
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if err := shipRotatedFile(ctx, m.shipper, m.cfg.Compression); err != nil {
+					logger.WithError(err).Error("Unable to ship log file")
+				}
+			case <-sizeTickerC:
+				oversized, err := logFileOversized(m.cfg.MaxSizeMB)
+				if err != nil {
+					logger.WithError(err).Warn("Unable to stat log file for size-triggered shipment")
+					continue
+				}
+				if !oversized {
+					continue
+				}
+				if err := shipRotatedFile(ctx, m.shipper, m.cfg.Compression); err != nil {
+					logger.WithError(err).Error("Unable to ship oversized log file")
+				}
+			}
+		}
+	}()
+
+	return nil
+}
+
+// Stop cancels the loop, waits for it to exit, and closes the underlying
+// shipper.
+func (m *ShipperManager) Stop() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if !m.started {
+		return nil
+	}
+
+	m.cancel()
+	<-m.done
+	m.started = false
+
+	return m.shipper.Close()
+}
+
+// shipRotatedFile rotates the active log file, compresses the sealed
+// previous file according to compression, and hands it to shipper. The
+// rotation and the ship happen atomically from the caller's point of
+// view: RotateFileSealed only returns once the old file descriptor has been
+// closed and renamed out of the way, so there is never a half-written
+// file handed to the shipper.
+func shipRotatedFile(ctx context.Context, shipper LogShipper, compression CompressionType) error {
+	sealedPath, err := RotateFileSealed()
+	if err != nil {
+		return fmt.Errorf("rotating log file: %w", err)
+	}
+
+	if sealedPath == "" {
+		return nil
+	}
+
+	shippedPath, err := compressFile(sealedPath, compression)
+	if err != nil {
+		return fmt.Errorf("compressing %s: %w", sealedPath, err)
+	}
+
+	if err := shipper.Ship(ctx, shippedPath); err != nil {
+		return fmt.Errorf("shipping %s: %w", shippedPath, err)
+	}
+
+	return os.Remove(shippedPath)
+}
+
+// logFileOversized reports whether the active log file is at or past
+// maxSizeMB.
+func logFileOversized(maxSizeMB int) (bool, error) {
+	info, err := os.Stat(logFilePath)
+	if err != nil {
+		return false, fmt.Errorf("stating %s: %w", logFilePath, err)
+	}
+
+	return info.Size() >= int64(maxSizeMB)*1024*1024, nil
+}
+
+// filenameOf returns the base name of a sealed log file path, suitable for
+// use as an object key or blob name by the cloud-storage shippers.
+func filenameOf(path string) string {
+	return filepath.Base(path)
+}
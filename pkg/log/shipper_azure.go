@@ -0,0 +1,117 @@
+package log
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+	"github.com/Azure/azure-sdk-for-go/sdk/azidentity"
+	"github.com/Azure/azure-storage-blob-go/azblob"
+)
+
+// azureStorageScope is the OAuth scope requested for a token used against
+// the Azure Storage data plane.
+const azureStorageScope = "https://storage.azure.com/.default"
+
+// AzureShipperConfig configures the Azure Blob Storage LogShipper.
+type AzureShipperConfig struct {
+	// Container is the destination blob container name.
+	Container string `description:"Azure Blob container name" json:"container,omitempty" toml:"container,omitempty" yaml:"container,omitempty"`
+	// Prefix is prepended to the blob name.
+	Prefix string `description:"Blob name prefix" json:"prefix,omitempty" toml:"prefix,omitempty" yaml:"prefix,omitempty"`
+	// AccountName is the storage account name.
+	AccountName string `description:"Azure storage account name" json:"accountName,omitempty" toml:"accountName,omitempty" yaml:"accountName,omitempty"`
+	// AccountKey is the storage account key. When unset, the account's
+	// managed identity is used instead.
+	AccountKey string `description:"Azure storage account key" json:"accountKey,omitempty" toml:"accountKey,omitempty" yaml:"accountKey,omitempty" loggable:"false"`
+}
+
+type azureShipper struct {
+	cfg          *AzureShipperConfig
+	containerURL azblob.ContainerURL
+}
+
+func newAzureShipper(cfg *AzureShipperConfig) (LogShipper, error) {
+	if cfg == nil || cfg.Container == "" || cfg.AccountName == "" {
+		return nil, fmt.Errorf("azure shipper requires an account name and a container")
+	}
+
+	credential, err := newAzureCredential(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("creating azure credential: %w", err)
+	}
+
+	pipeline := azblob.NewPipeline(credential, azblob.PipelineOptions{})
+
+	containerURL := azblob.NewContainerURL(
+		azureServiceURL(cfg.AccountName, cfg.Container),
+		pipeline,
+	)
+
+	return &azureShipper{cfg: cfg, containerURL: containerURL}, nil
+}
+
+func (s *azureShipper) Ship(ctx context.Context, path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("opening %s: %w", path, err)
+	}
+	defer file.Close()
+
+	blobName := s.cfg.Prefix + filenameOf(path)
+	blockBlobURL := s.containerURL.NewBlockBlobURL(blobName)
+
+	if _, err := azblob.UploadFileToBlockBlob(ctx, file, blockBlobURL, azblob.UploadToBlockBlobOptions{}); err != nil {
+		return fmt.Errorf("uploading to %s/%s: %w", s.cfg.Container, blobName, err)
+	}
+
+	return nil
+}
+
+func (s *azureShipper) Close() error {
+	return nil
+}
+
+func azureServiceURL(accountName, container string) url.URL {
+	u, _ := url.Parse(fmt.Sprintf("https://%s.blob.core.windows.net/%s", accountName, container))
+	return *u
+}
+
+// newAzureCredential returns a shared-key credential when AccountKey is
+// set, and otherwise falls through to the account's managed/workload
+// identity via azidentity's default credential chain (environment,
+// workload identity, managed identity, Azure CLI, in that order).
+func newAzureCredential(cfg *AzureShipperConfig) (azblob.Credential, error) {
+	if cfg.AccountKey != "" {
+		return azblob.NewSharedKeyCredential(cfg.AccountName, cfg.AccountKey)
+	}
+
+	return newAzureManagedIdentityCredential()
+}
+
+func newAzureManagedIdentityCredential() (azblob.Credential, error) {
+	chain, err := azidentity.NewDefaultAzureCredential(nil)
+	if err != nil {
+		return nil, fmt.Errorf("resolving azure managed identity: %w", err)
+	}
+
+	token, err := chain.GetToken(context.Background(), policy.TokenRequestOptions{Scopes: []string{azureStorageScope}})
+	if err != nil {
+		return nil, fmt.Errorf("fetching azure managed identity token: %w", err)
+	}
+
+	return azblob.NewTokenCredential(token.Token, func(tc azblob.TokenCredential) time.Duration {
+		refreshed, err := chain.GetToken(context.Background(), policy.TokenRequestOptions{Scopes: []string{azureStorageScope}})
+		if err != nil {
+			WithoutContext().WithError(err).Error("Unable to refresh azure managed identity token")
+			return 0 // stop the refresher; the next Ship call will fail with an expired-token error instead of looping forever.
+		}
+
+		tc.SetToken(refreshed.Token)
+
+		return time.Until(refreshed.ExpiresOn) - time.Minute
+	}), nil
+}
@@ -0,0 +1,72 @@
+package log
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"cloud.google.com/go/storage"
+	"google.golang.org/api/option"
+)
+
+// GCSShipperConfig configures the Google Cloud Storage LogShipper.
+type GCSShipperConfig struct {
+	// Bucket is the destination bucket name.
+	Bucket string `description:"GCS bucket name" json:"bucket,omitempty" toml:"bucket,omitempty" yaml:"bucket,omitempty"`
+	// Prefix is prepended to the object name.
+	Prefix string `description:"GCS object prefix" json:"prefix,omitempty" toml:"prefix,omitempty" yaml:"prefix,omitempty"`
+	// CredentialsFile is an optional path to a service account JSON key.
+	// When unset, workload identity / application default credentials
+	// are used.
+	CredentialsFile string `description:"Path to a service account JSON key file" json:"credentialsFile,omitempty" toml:"credentialsFile,omitempty" yaml:"credentialsFile,omitempty"`
+}
+
+type gcsShipper struct {
+	cfg    *GCSShipperConfig
+	client *storage.Client
+}
+
+func newGCSShipper(cfg *GCSShipperConfig) (LogShipper, error) {
+	if cfg == nil || cfg.Bucket == "" {
+		return nil, fmt.Errorf("gcs shipper requires a bucket")
+	}
+
+	var opts []option.ClientOption
+	if cfg.CredentialsFile != "" {
+		opts = append(opts, option.WithCredentialsFile(cfg.CredentialsFile))
+	}
+
+	client, err := storage.NewClient(context.Background(), opts...)
+	if err != nil {
+		return nil, fmt.Errorf("creating GCS client: %w", err)
+	}
+
+	return &gcsShipper{cfg: cfg, client: client}, nil
+}
+
+func (s *gcsShipper) Ship(ctx context.Context, path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("opening %s: %w", path, err)
+	}
+	defer file.Close()
+
+	object := s.cfg.Prefix + filenameOf(path)
+
+	w := s.client.Bucket(s.cfg.Bucket).Object(object).NewWriter(ctx)
+	if _, err := io.Copy(w, file); err != nil {
+		_ = w.Close()
+		return fmt.Errorf("uploading to gs://%s/%s: %w", s.cfg.Bucket, object, err)
+	}
+
+	if err := w.Close(); err != nil {
+		return fmt.Errorf("finalizing gs://%s/%s: %w", s.cfg.Bucket, object, err)
+	}
+
+	return nil
+}
+
+func (s *gcsShipper) Close() error {
+	return s.client.Close()
+}
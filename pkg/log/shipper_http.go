@@ -0,0 +1,74 @@
+package log
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"time"
+)
+
+// HTTPShipperConfig configures the generic HTTP POST LogShipper.
+type HTTPShipperConfig struct {
+	// URL is the endpoint the sealed log file is POSTed to.
+	URL string `description:"Destination URL" json:"url,omitempty" toml:"url,omitempty" yaml:"url,omitempty"`
+	// Headers are added to every request, e.g. for bearer tokens.
+	Headers map[string]string `description:"Extra HTTP headers" json:"headers,omitempty" toml:"headers,omitempty" yaml:"headers,omitempty"`
+	// Timeout bounds a single shipment request.
+	Timeout time.Duration `description:"Request timeout" json:"timeout,omitempty" toml:"timeout,omitempty" yaml:"timeout,omitempty"`
+}
+
+type httpShipper struct {
+	cfg    *HTTPShipperConfig
+	client *http.Client
+}
+
+func newHTTPShipper(cfg *HTTPShipperConfig) (LogShipper, error) {
+	if cfg == nil || cfg.URL == "" {
+		return nil, fmt.Errorf("http shipper requires a URL")
+	}
+
+	timeout := cfg.Timeout
+	if timeout <= 0 {
+		timeout = 30 * time.Second
+	}
+
+	return &httpShipper{
+		cfg:    cfg,
+		client: &http.Client{Timeout: timeout},
+	}, nil
+}
+
+func (s *httpShipper) Ship(ctx context.Context, path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("opening %s: %w", path, err)
+	}
+	defer file.Close()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.cfg.URL, file)
+	if err != nil {
+		return fmt.Errorf("creating http shipper request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/octet-stream")
+	for k, v := range s.cfg.Headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("posting %s to %s: %w", path, s.cfg.URL, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("http shipper received status %s from %s", resp.Status, s.cfg.URL)
+	}
+
+	return nil
+}
+
+func (s *httpShipper) Close() error {
+	s.client.CloseIdleConnections()
+	return nil
+}
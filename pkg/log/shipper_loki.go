@@ -0,0 +1,118 @@
+package log
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+	"time"
+)
+
+// LokiShipperConfig configures the Grafana Loki LogShipper.
+type LokiShipperConfig struct {
+	// PushURL is the Loki push endpoint, e.g. "http://loki:3100/loki/api/v1/push".
+	PushURL string `description:"Loki push API URL" json:"pushURL,omitempty" toml:"pushURL,omitempty" yaml:"pushURL,omitempty"`
+	// Labels are static stream labels attached to every shipped entry.
+	Labels map[string]string `description:"Static labels attached to the Loki stream" json:"labels,omitempty" toml:"labels,omitempty" yaml:"labels,omitempty"`
+	// TenantID sets the X-Scope-OrgID header for multi-tenant Loki.
+	TenantID string `description:"Loki tenant ID (X-Scope-OrgID)" json:"tenantID,omitempty" toml:"tenantID,omitempty" yaml:"tenantID,omitempty"`
+}
+
+type lokiShipper struct {
+	cfg    *LokiShipperConfig
+	client *http.Client
+}
+
+func newLokiShipper(cfg *LokiShipperConfig) (LogShipper, error) {
+	if cfg == nil || cfg.PushURL == "" {
+		return nil, fmt.Errorf("loki shipper requires a push URL")
+	}
+
+	return &lokiShipper{
+		cfg:    cfg,
+		client: &http.Client{Timeout: 30 * time.Second},
+	}, nil
+}
+
+// lokiPushRequest mirrors Loki's push API payload.
+type lokiPushRequest struct {
+	Streams []lokiStream `json:"streams"`
+}
+
+type lokiStream struct {
+	Stream map[string]string `json:"stream"`
+	Values [][2]string       `json:"values"`
+}
+
+func (s *lokiShipper) Ship(ctx context.Context, path string) error {
+	lines, err := readLines(path)
+	if err != nil {
+		return fmt.Errorf("reading %s: %w", path, err)
+	}
+
+	now := time.Now().UnixNano()
+	values := make([][2]string, 0, len(lines))
+	for _, line := range lines {
+		values = append(values, [2]string{strconv.FormatInt(now, 10), line})
+	}
+
+	payload := lokiPushRequest{
+		Streams: []lokiStream{
+			{
+				Stream: s.cfg.Labels,
+				Values: values,
+			},
+		},
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("marshaling loki push request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.cfg.PushURL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("creating loki push request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if s.cfg.TenantID != "" {
+		req.Header.Set("X-Scope-OrgID", s.cfg.TenantID)
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("pushing to loki: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("loki push returned status %s", resp.Status)
+	}
+
+	return nil
+}
+
+func (s *lokiShipper) Close() error {
+	s.client.CloseIdleConnections()
+	return nil
+}
+
+func readLines(path string) ([]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var lines []string
+	for _, line := range bytes.Split(data, []byte("\n")) {
+		if len(line) == 0 {
+			continue
+		}
+		lines = append(lines, string(line))
+	}
+
+	return lines, nil
+}
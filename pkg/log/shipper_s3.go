@@ -0,0 +1,78 @@
+package log
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3/s3manager"
+)
+
+// S3ShipperConfig configures the S3 LogShipper.
+type S3ShipperConfig struct {
+	// Bucket is the destination bucket name.
+	Bucket string `description:"S3 bucket name" json:"bucket,omitempty" toml:"bucket,omitempty" yaml:"bucket,omitempty"`
+	// Prefix is prepended to the object key, e.g. "traefik-logs/<hostname>/".
+	Prefix string `description:"S3 key prefix" json:"prefix,omitempty" toml:"prefix,omitempty" yaml:"prefix,omitempty"`
+	// Region is the AWS region hosting the bucket.
+	Region string `description:"AWS region" json:"region,omitempty" toml:"region,omitempty" yaml:"region,omitempty"`
+	// AccessKeyID and SecretAccessKey are optional static credentials.
+	// When unset, the default AWS credential chain (including IAM roles
+	// and workload identity) is used.
+	AccessKeyID     string `description:"AWS access key ID" json:"accessKeyID,omitempty" toml:"accessKeyID,omitempty" yaml:"accessKeyID,omitempty"`
+	SecretAccessKey string `description:"AWS secret access key" json:"secretAccessKey,omitempty" toml:"secretAccessKey,omitempty" yaml:"secretAccessKey,omitempty" loggable:"false"`
+}
+
+type s3Shipper struct {
+	cfg      *S3ShipperConfig
+	uploader *s3manager.Uploader
+}
+
+func newS3Shipper(cfg *S3ShipperConfig) (LogShipper, error) {
+	if cfg == nil || cfg.Bucket == "" {
+		return nil, fmt.Errorf("s3 shipper requires a bucket")
+	}
+
+	awsCfg := aws.NewConfig().WithRegion(cfg.Region)
+	if cfg.AccessKeyID != "" {
+		awsCfg = awsCfg.WithCredentials(credentials.NewStaticCredentials(cfg.AccessKeyID, cfg.SecretAccessKey, ""))
+	}
+
+	sess, err := session.NewSession(awsCfg)
+	if err != nil {
+		return nil, fmt.Errorf("creating AWS session: %w", err)
+	}
+
+	return &s3Shipper{
+		cfg:      cfg,
+		uploader: s3manager.NewUploader(sess),
+	}, nil
+}
+
+func (s *s3Shipper) Ship(ctx context.Context, path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("opening %s: %w", path, err)
+	}
+	defer file.Close()
+
+	key := s.cfg.Prefix + filenameOf(path)
+
+	_, err = s.uploader.UploadWithContext(ctx, &s3manager.UploadInput{
+		Bucket: aws.String(s.cfg.Bucket),
+		Key:    aws.String(key),
+		Body:   file,
+	})
+	if err != nil {
+		return fmt.Errorf("uploading to s3://%s/%s: %w", s.cfg.Bucket, key, err)
+	}
+
+	return nil
+}
+
+func (s *s3Shipper) Close() error {
+	return nil
+}
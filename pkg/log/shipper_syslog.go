@@ -0,0 +1,61 @@
+package log
+
+import (
+	"context"
+	"fmt"
+	"log/syslog"
+)
+
+// SyslogShipperConfig configures the syslog LogShipper. Records are sent
+// through the standard library's log/syslog writer, which uses a BSD-style
+// "<PRI>timestamp hostname tag[pid]: message" header; the RFC 5424 message
+// format is not produced.
+type SyslogShipperConfig struct {
+	// Network is "udp" or "tcp". Empty selects the local syslog daemon.
+	Network string `description:"Network used to reach the syslog collector (udp, tcp, or empty for local)" json:"network,omitempty" toml:"network,omitempty" yaml:"network,omitempty"`
+	// Address is the "host:port" of the syslog collector.
+	Address string `description:"Syslog collector address" json:"address,omitempty" toml:"address,omitempty" yaml:"address,omitempty"`
+	// Tag identifies Traefik in the forwarded records.
+	Tag string `description:"Syslog tag" json:"tag,omitempty" toml:"tag,omitempty" yaml:"tag,omitempty"`
+}
+
+type syslogShipper struct {
+	writer *syslog.Writer
+}
+
+func newSyslogShipper(cfg *SyslogShipperConfig) (LogShipper, error) {
+	if cfg == nil {
+		return nil, fmt.Errorf("syslog shipper requires a configuration")
+	}
+
+	tag := cfg.Tag
+	if tag == "" {
+		tag = "traefik"
+	}
+
+	writer, err := syslog.Dial(cfg.Network, cfg.Address, syslog.LOG_INFO|syslog.LOG_DAEMON, tag)
+	if err != nil {
+		return nil, fmt.Errorf("dialing syslog collector: %w", err)
+	}
+
+	return &syslogShipper{writer: writer}, nil
+}
+
+func (s *syslogShipper) Ship(ctx context.Context, path string) error {
+	lines, err := readLines(path)
+	if err != nil {
+		return fmt.Errorf("reading %s: %w", path, err)
+	}
+
+	for _, line := range lines {
+		if err := s.writer.Info(line); err != nil {
+			return fmt.Errorf("forwarding line to syslog: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (s *syslogShipper) Close() error {
+	return s.writer.Close()
+}
@@ -0,0 +1,134 @@
+package log
+
+import (
+	"context"
+	"log/slog"
+	"runtime"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+)
+
+// SlogHandler is a slog.Handler backed by a Traefik Logger, so that
+// middlewares written against the stdlib log/slog API emit through the
+// same pipeline (formatter, hooks, sampling) as the rest of Traefik.
+type SlogHandler struct {
+	logger Logger
+	groups []string
+	// hasAttrs is true once WithAttrs has been called at least once, so
+	// Handle knows this handler carries its own accumulated state and
+	// must not discard it by substituting a logger pulled from ctx.
+	hasAttrs bool
+}
+
+// NewSlogHandler wraps logger as a slog.Handler.
+func NewSlogHandler(logger Logger) *SlogHandler {
+	return &SlogHandler{logger: logger}
+}
+
+// Enabled reports whether a record at the given level would be emitted.
+func (h *SlogHandler) Enabled(_ context.Context, level slog.Level) bool {
+	return logrus.GetLevel() >= fromSlogLevel(level)
+}
+
+// Handle emits the record through the underlying Logger, attaching the
+// standard time/level/msg/caller attributes plus whatever fields the
+// record carries.
+//
+// A handler produced by WithAttrs (or by WithGroup on such a handler)
+// carries its own accumulated fields, so it always logs through h.logger:
+// falling back to GetLogger(ctx) in that case would silently drop
+// everything accumulated so far. A handler with no attrs of its own -
+// typically the one installed as slog's default, or one only derived from
+// it via WithGroup - defers to the contextual logger, so it still picks up
+// per-request fields (router, service, ...) threaded through ctx.
+func (h *SlogHandler) Handle(ctx context.Context, record slog.Record) error {
+	logger := h.logger
+	if !h.hasAttrs && ctx != nil {
+		logger = GetLogger(ctx)
+	}
+
+	fields := make(logrus.Fields, record.NumAttrs()+1)
+	if record.PC != 0 {
+		if frame, ok := callerFrame(record.PC); ok {
+			fields["caller"] = frame
+		}
+	}
+
+	record.Attrs(func(attr slog.Attr) bool {
+		fields[h.prefixedKey(attr.Key)] = attr.Value.Any()
+		return true
+	})
+
+	logger.WithFields(fields).Log(fromSlogLevel(record.Level), record.Message)
+
+	return nil
+}
+
+// WithAttrs returns a new handler whose records always carry attrs, folded
+// into the logger's own field set so Handle never has to choose between
+// them and a contextual logger.
+func (h *SlogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	fields := make(logrus.Fields, len(attrs))
+	for _, attr := range attrs {
+		fields[h.prefixedKey(attr.Key)] = attr.Value.Any()
+	}
+
+	return &SlogHandler{
+		logger:   h.logger.WithFields(fields),
+		groups:   h.groups,
+		hasAttrs: true,
+	}
+}
+
+// WithGroup returns a handler that prefixes every attribute key added from
+// this point on - via WithAttrs or directly on a record - with "name.",
+// nesting with "." for repeated groups, matching slog's own group
+// semantics. Traefik's field model is otherwise flat: there is no nested
+// namespace, just a dotted key.
+func (h *SlogHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+
+	groups := make([]string, len(h.groups)+1)
+	copy(groups, h.groups)
+	groups[len(h.groups)] = name
+
+	return &SlogHandler{
+		logger:   h.logger,
+		groups:   groups,
+		hasAttrs: h.hasAttrs,
+	}
+}
+
+func (h *SlogHandler) prefixedKey(key string) string {
+	if len(h.groups) == 0 {
+		return key
+	}
+
+	return strings.Join(h.groups, ".") + "." + key
+}
+
+func fromSlogLevel(level slog.Level) logrus.Level {
+	switch {
+	case level >= slog.LevelError:
+		return logrus.ErrorLevel
+	case level >= slog.LevelWarn:
+		return logrus.WarnLevel
+	case level >= slog.LevelInfo:
+		return logrus.InfoLevel
+	default:
+		return logrus.DebugLevel
+	}
+}
+
+func callerFrame(pc uintptr) (string, bool) {
+	frames := runtime.CallersFrames([]uintptr{pc})
+	frame, _ := frames.Next()
+	if frame.File == "" {
+		return "", false
+	}
+
+	return frame.Function, true
+}